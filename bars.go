@@ -0,0 +1,112 @@
+package chartjs
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+// BarOptions sizes the bars of a Bar dataset. They are always sent with the datasets, where
+// Chart.js 2.9 reads them; those of an Axis are sent with each Bar dataset on it that doesn't set
+// its own and also on the axis itself, where earlier versions read them. Earlier versions ignore
+// the BarOptions of a Dataset.
+type BarOptions struct {
+	// BarPercentage is the fraction of its slot that each bar fills (0.9 by default).
+	BarPercentage float64 `json:"barPercentage,omitempty"`
+	// CategoryPercentage is the fraction of each category that its bars fill (0.8 by default).
+	CategoryPercentage float64 `json:"categoryPercentage,omitempty"`
+	// BarThickness sets the width of each bar in pixels, ignoring the percentages.
+	BarThickness    float64 `json:"barThickness,omitempty"`
+	MaxBarThickness float64 `json:"maxBarThickness,omitempty"`
+	// MinBarLength is the least length in pixels of a bar so that small values are visible.
+	MinBarLength float64 `json:"minBarLength,omitempty"`
+}
+
+// merge returns the options of b with those that are unset taken from o.
+func (b *BarOptions) merge(o *BarOptions) *BarOptions {
+	if b == nil || o == nil {
+		if b == nil {
+			return o
+		}
+		return b
+	}
+	m := *b
+	for _, f := range []struct{ v, o *float64 }{
+		{&m.BarPercentage, &o.BarPercentage},
+		{&m.CategoryPercentage, &o.CategoryPercentage},
+		{&m.BarThickness, &o.BarThickness},
+		{&m.MaxBarThickness, &o.MaxBarThickness},
+		{&m.MinBarLength, &o.MinBarLength},
+	} {
+		if *f.v == 0 {
+			*f.v = *f.o
+		}
+	}
+	return &m
+}
+
+// edge is the side of a bar whose border is skipped.
+type edge int
+
+const (
+	_ edge = iota
+	// SkipBottom skips the border at the base of the bar (this is the default).
+	SkipBottom
+	SkipLeft
+	SkipTop
+	SkipRight
+	// SkipNone draws the border on every side.
+	SkipNone
+)
+
+var edges = [...]string{
+	"",
+	"bottom",
+	"left",
+	"top",
+	"right",
+	"false",
+}
+
+func (e edge) MarshalJSON() ([]byte, error) {
+	if e == SkipNone {
+		return []byte(edges[e]), nil
+	}
+	return []byte(`"` + edges[e] + `"`), nil
+}
+
+// SignColors returns pos for each value that is zero or more and neg for the others. It can be
+// used as the BackgroundColors of a Bar dataset to color the bars by sign.
+func SignColors(values []float64, pos, neg types.RGBA) []types.RGBA {
+	colors := make([]types.RGBA, len(values))
+	for i, v := range values {
+		colors[i] = pos
+		if v < 0 {
+			colors[i] = neg
+		}
+	}
+	return colors
+}
+
+// marshalColorsJSON returns the colors as a JSON field with the given name and a trailing comma.
+// There must be a color for each of the n values.
+func marshalColorsJSON(name string, colors []types.RGBA, n int) ([]byte, error) {
+	if len(colors) != n {
+		return nil, fmt.Errorf("chart: %d colors in %s for %d values", len(colors), name, n)
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, 24*len(colors)))
+	fmt.Fprintf(buf, `"%s":[`, name)
+	for i, c := range colors {
+		if i > 0 {
+			buf.WriteRune(',')
+		}
+		b, err := c.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	buf.WriteString("],")
+	return buf.Bytes(), nil
+}
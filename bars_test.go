@@ -0,0 +1,77 @@
+package chartjs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+func TestBarOptions(t *testing.T) {
+	c := Chart{Type: Bar, Data: Data{Labels: []string{"a", "b"}}}
+	c.AddDataset(Dataset{Data: Ys{1, 2}, BarOptions: &BarOptions{BarPercentage: 1}, BorderSkipped: SkipNone})
+	c.AddDataset(Dataset{Type: Line, Data: Ys{3, 4}})
+	c.AddXAxis(Axis{Type: Category, Bar: &BarOptions{BarPercentage: 0.5, CategoryPercentage: 1, MaxBarThickness: 20}})
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	var v struct {
+		Data struct {
+			Datasets []map[string]interface{}
+		}
+		Options struct {
+			Scales struct {
+				XAxes []map[string]interface{}
+			}
+		}
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("error unmarshaling chart: %+v", err)
+	}
+	d := v.Data.Datasets[0]
+	// the options of the dataset win over those of the axis.
+	if d["barPercentage"] != 1.0 || d["categoryPercentage"] != 1.0 || d["maxBarThickness"] != 20.0 {
+		t.Fatalf("unexpected bar options: %v", d)
+	}
+	if d["borderSkipped"] != false {
+		t.Fatalf("expected borderSkipped false, got %v", d["borderSkipped"])
+	}
+	if _, ok := v.Data.Datasets[1]["barPercentage"]; ok {
+		t.Fatalf("expected no bar options on a line dataset")
+	}
+	// and those of the axis are kept on it for Chart.js before 2.9.
+	if x := v.Options.Scales.XAxes[0]; x["barPercentage"] != 0.5 || x["maxBarThickness"] != 20.0 {
+		t.Fatalf("expected the bar options on the axis, got %v", x)
+	}
+	if c.Data.Datasets[0].BarOptions.CategoryPercentage != 0 {
+		t.Fatalf("expected marshaling to leave the chart unchanged")
+	}
+}
+
+func TestBarColors(t *testing.T) {
+	ys := Ys{1, -2, 3}
+	pos, neg := types.RGBA{0, 0, 255, 255}, types.RGBA{255, 0, 0, 255}
+	colors := SignColors(ys, pos, neg)
+	if colors[0] != pos || colors[1] != neg || colors[2] != pos {
+		t.Fatalf("unexpected colors: %v", colors)
+	}
+	d := Dataset{Data: ys, BackgroundColor: &pos, BackgroundColors: colors}
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("error marshaling dataset: %+v", err)
+	}
+	if want := `"backgroundColor":["rgba(0, 0, 255, 1.000)","rgba(255, 0, 0, 1.000)","rgba(0, 0, 255, 1.000)"]`; !strings.Contains(string(b), want) {
+		t.Fatalf("expected %s in %s", want, b)
+	}
+	if strings.Count(string(b), "backgroundColor") != 1 {
+		t.Fatalf("expected one backgroundColor in %s", b)
+	}
+
+	d.BorderColors = colors[:2]
+	if _, err := json.Marshal(d); err == nil {
+		t.Fatalf("expected error with too few colors")
+	}
+}
@@ -0,0 +1,98 @@
+package chartjs
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/brentp/go-chartjs/stats"
+)
+
+// BoxPlotJS holds the path to the hosted plugin that draws BoxPlot and Violin charts.
+var BoxPlotJS = "https://unpkg.com/chartjs-chart-box-and-violin-plot@2.4.0/build/Chart.BoxPlot.js"
+
+// ViolinPoints is the number of points at which the density of each Violin is estimated.
+var ViolinPoints = 100
+
+// Samples is the data for BoxPlot and Violin charts. It holds the raw values for each
+// category, in the order of Data.Labels. The summaries are computed in go.
+type Samples interface {
+	Samples() [][]float64
+}
+
+// RawSamples satisfies Samples with one slice of values per category.
+type RawSamples [][]float64
+
+// Samples satisfies the Samples interface.
+func (s RawSamples) Samples() [][]float64 {
+	return s
+}
+
+type whiskerMode int
+
+const (
+	// Tukey whiskers extend to the most extreme values within 1.5 IQR of the quartiles
+	// and show the values beyond them as outliers (this is the default).
+	Tukey whiskerMode = iota
+	// MinMax whiskers extend to the minimum and maximum.
+	MinMax
+)
+
+func marshalSamplesJSON(s Samples, t chartType, w whiskerMode, format string) ([]byte, error) {
+	samples := s.Samples()
+	buf := bytes.NewBuffer(make([]byte, 0, 64*len(samples)))
+	buf.WriteRune('[')
+	for i, vals := range samples {
+		if i > 0 {
+			buf.WriteRune(',')
+		}
+		sorted := stats.Sorted(vals)
+		if len(sorted) == 0 {
+			// a category without values is left empty.
+			buf.WriteString("null")
+			continue
+		}
+		b, err := stats.Summarize(sorted, w == Tukey)
+		if err != nil {
+			return nil, fmt.Errorf("chart: sample %d: %s", i, err)
+		}
+		f := func(v float64) string { return formatFloat(format, v) }
+		lo, hi := b.WhiskerMin, b.WhiskerMax
+		if t == Violin {
+			// the violin is drawn over the full range of the data.
+			lo, hi = b.Min, b.Max
+		}
+		fmt.Fprintf(buf, `{"min":%s,"q1":%s,"median":%s,"q3":%s,"max":%s,"whiskerMin":%s,"whiskerMax":%s,"outliers":[`,
+			f(lo), f(b.Q1), f(b.Median), f(b.Q3), f(hi), f(b.WhiskerMin), f(b.WhiskerMax))
+		for j, o := range b.Outliers {
+			if j > 0 {
+				buf.WriteRune(',')
+			}
+			buf.WriteString(f(o))
+		}
+		buf.WriteRune(']')
+		if t == Violin {
+			c := stats.KDEAt(sorted, violinBandwidth(sorted), stats.Linspace(b.Min, b.Max, ViolinPoints))
+			buf.WriteString(`,"coords":[`)
+			for j, v := range c.X {
+				if j > 0 {
+					buf.WriteRune(',')
+				}
+				fmt.Fprintf(buf, `{"v":%s,"estimate":%g}`, f(v), c.Y[j])
+			}
+			buf.WriteRune(']')
+		}
+		buf.WriteRune('}')
+	}
+	buf.WriteRune(']')
+	return buf.Bytes(), nil
+}
+
+// violinBandwidth returns the bandwidth used to estimate the density of the sorted values.
+func violinBandwidth(sorted []float64) float64 {
+	bw := stats.Silverman(sorted)
+	if bw <= 0 {
+		// all values are equal.
+		return 1
+	}
+	return bw
+}
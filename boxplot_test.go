@@ -0,0 +1,86 @@
+package chartjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBoxPlot(t *testing.T) {
+	s := RawSamples{{1, 2, 3, 4, 5, 6, 7, 8, 100}, {2, 2, 3, 3, 4}}
+	chart := Chart{Type: BoxPlot}
+	chart.AddDataset(Dataset{Samples: s, Label: "box"})
+	chart.Data.Labels = []string{"a", "b"}
+
+	b, err := json.Marshal(chart)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	if !strings.Contains(string(b), `"min":1.00,"q1":3.00,"median":5.00,"q3":7.00,"max":8.00`) {
+		t.Fatalf("expected box summary in %s", b)
+	}
+	if !strings.Contains(string(b), `"outliers":[100.00]`) {
+		t.Fatalf("expected outlier in %s", b)
+	}
+	if strings.Contains(string(b), "coords") {
+		t.Fatalf("expected no density for a box plot")
+	}
+
+	var buf bytes.Buffer
+	if err := chart.SaveHTML(&buf, nil); err != nil {
+		t.Fatalf("error saving chart: %+v", err)
+	}
+	if !strings.Contains(buf.String(), BoxPlotJS) {
+		t.Fatalf("expected box plot plugin script in html")
+	}
+}
+
+func TestViolin(t *testing.T) {
+	s := RawSamples{{1, 2, 3, 4, 5, 6, 7, 8, 100}}
+	chart := Chart{Type: Violin}
+	chart.AddDataset(Dataset{Samples: s, Whiskers: MinMax})
+
+	b, err := json.Marshal(chart)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	var v struct {
+		Data struct {
+			Datasets []struct {
+				Type string
+				Data []struct {
+					Max    float64
+					Coords []struct{ V, Estimate float64 }
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("error unmarshaling chart: %+v", err)
+	}
+	d := v.Data.Datasets[0]
+	if d.Type != "violin" || d.Data[0].Max != 100 || len(d.Data[0].Coords) != ViolinPoints {
+		t.Fatalf("unexpected violin: %s", b)
+	}
+
+	// NaNs are left out of the density and a category without values is null.
+	nan := Chart{Type: Violin}
+	nan.AddDataset(Dataset{Samples: RawSamples{{1, 2, 3, 4, 5, 6, 7, 8, 100, math.NaN(), math.NaN()}, {}}})
+	nb, err := json.Marshal(nan)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	if i := bytes.Index(b, []byte(`"coords"`)); i < 0 || !bytes.Contains(nb, b[i:bytes.Index(b, []byte("]}]"))]) {
+		t.Fatalf("expected the same density without the NaNs in %s", nb)
+	}
+	if !bytes.Contains(nb, []byte(`]},null]`)) {
+		t.Fatalf("expected null for the empty category in %s", nb)
+	}
+
+	chart.Type = Bar
+	if _, err := json.Marshal(chart); err == nil {
+		t.Fatalf("expected error with Samples on a bar chart")
+	}
+}
@@ -0,0 +1,198 @@
+package chartjs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/brentp/go-chartjs/internal/nice"
+)
+
+// Break is a range of values that is left out of a Linear axis so that a few outliers don't
+// squash the rest of the data. The values are moved in Go so that the range takes no space, a
+// zig-zag is drawn where it was and the ticks and tooltips show the original values.
+type Break struct {
+	From, To float64
+}
+
+// breaks returns the breaks of the axis in order.
+func (a Axis) breaks() []Break {
+	if a.Type != Linear || len(a.Breaks) == 0 {
+		return nil
+	}
+	b := append([]Break{}, a.Breaks...)
+	sort.Slice(b, func(i, j int) bool { return b[i].From < b[j].From })
+	return b
+}
+
+// validBreaks returns an error if the breaks of the axis are empty or overlap.
+func (a Axis) validBreaks() error {
+	if len(a.Breaks) == 0 {
+		return nil
+	}
+	if a.Type != Linear {
+		return fmt.Errorf("chart: axis %q: breaks are only supported on Linear axes", a.ID)
+	}
+	b := a.breaks()
+	for i, r := range b {
+		if !(r.From < r.To) {
+			return fmt.Errorf("chart: axis %q: break from %v to %v is empty", a.ID, r.From, r.To)
+		}
+		if i > 0 && r.From < b[i-1].To {
+			return fmt.Errorf("chart: axis %q: breaks from %v and %v overlap", a.ID, b[i-1].From, r.From)
+		}
+	}
+	return nil
+}
+
+// removeBreaks returns v placed on an axis with breaks b. Values within a break are NaN.
+func removeBreaks(b []Break, v float64) float64 {
+	shift := 0.0
+	for _, r := range b {
+		if v >= r.To {
+			shift += r.To - r.From
+		} else if v > r.From {
+			return math.NaN()
+		}
+	}
+	return v - shift
+}
+
+// restoreBreaks returns the value at v on an axis with breaks b. A break is shown by its From.
+func restoreBreaks(b []Break, v float64) float64 {
+	for _, r := range b {
+		if v <= r.From {
+			break
+		}
+		v += r.To - r.From
+	}
+	return v
+}
+
+// breakTicks returns the ticks of an axis with breaks b that cover min to max, which are values on
+// the axis. The ticks are multiples of a step in each range between the breaks.
+func breakTicks(b []Break, min, max float64) ([]float64, []string) {
+	if !(max > min) {
+		return nil, nil
+	}
+	step := nice.Step((max - min) / 6)
+	lo, hi := restoreBreaks(b, min), restoreBreaks(b, max)
+	eps := step * 1e-9
+	var ticks []float64
+	var labels []string
+	start := lo
+	for i := 0; i <= len(b); i++ {
+		end := hi
+		if i < len(b) {
+			end = math.Min(b[i].From, hi)
+		}
+		for v := math.Ceil((start-eps)/step) * step; v <= end+eps; v += step {
+			if math.Abs(v) < eps {
+				v = 0
+			}
+			// the zig-zag is drawn at the ends of a break.
+			if (i < len(b) && math.Abs(v-b[i].From) < eps) || (i > 0 && math.Abs(v-b[i-1].To) < eps) {
+				continue
+			}
+			ticks = append(ticks, removeBreaks(b, v))
+			labels = append(labels, strconv.FormatFloat(v, 'g', 6, 64))
+		}
+		if i < len(b) {
+			start = math.Max(b[i].To, lo)
+		}
+	}
+	return ticks, labels
+}
+
+// breaksPlugin places the ticks of the axes that Axis.MarshalJSON sends with "breaks" as
+// Axis.Ticks does, draws a zig-zag at each break and shows the original values in tooltips.
+const breaksPlugin = `
+(function() {
+	var restore = function(b, v) {
+		for (var i = 0; i < b.length && v > b[i].from; i++) { v += b[i].to - b[i].from; }
+		return v;
+	};
+	var remove = function(b, v) {
+		var shift = 0;
+		b.forEach(function(r) { if (v >= r.to) { shift += r.to - r.from; } });
+		return v - shift;
+	};
+	var niceStep = function(x) {
+		var p = Math.pow(10, Math.floor(Math.log10(x))), f = x / p;
+		return (f < 1.5 ? 1 : f < 3 ? 2 : f < 7 ? 5 : 10) * p;
+	};
+	var format = function(v) { return String(parseFloat(v.toPrecision(6))); };
+	Chart.plugins.register({
+		beforeInit: function(chart) {
+			var s = chart.options.scales || {}, any = false;
+			(s.xAxes || []).concat(s.yAxes || []).forEach(function(a) {
+				var b = a.breaks;
+				if (!b) { return; }
+				any = true;
+				a.afterBuildTicks = function(scale) {
+					var min = Math.min(scale.min, scale.max), max = Math.max(scale.min, scale.max);
+					if (!(max > min)) { return; }
+					var step = niceStep((max - min) / 6), eps = step * 1e-9, t = [];
+					var lo = restore(b, min), hi = restore(b, max), start = lo;
+					for (var i = 0; i <= b.length; i++) {
+						var end = i < b.length ? Math.min(b[i].from, hi) : hi;
+						for (var v = Math.ceil((start - eps) / step) * step; v <= end + eps; v += step) {
+							if ((i < b.length && Math.abs(v - b[i].from) < eps) || (i > 0 && Math.abs(v - b[i - 1].to) < eps)) { continue; }
+							t.push(remove(b, Math.abs(v) < eps ? 0 : v));
+						}
+						if (i < b.length) { start = Math.max(b[i].to, lo); }
+					}
+					return t.length ? t : undefined;
+				};
+				a.ticks = a.ticks || {};
+				a.ticks.callback = function(v) { return format(restore(b, v)); };
+			});
+			if (!any) { return; }
+			var cb = chart.options.tooltips.callbacks, label = cb.label, title = cb.title;
+			var original = function(item) {
+				var meta = chart.getDatasetMeta(item.datasetIndex), x = chart.scales[meta.xAxisID], y = chart.scales[meta.yAxisID];
+				item = Chart.helpers.extend({}, item);
+				if (y && y.options.breaks) { item.yLabel = item.value = format(restore(y.options.breaks, +item.yLabel)); }
+				if (x && x.options.breaks) { item.xLabel = item.label = format(restore(x.options.breaks, +item.xLabel)); }
+				return item;
+			};
+			cb.label = function(item, data) { return label.call(this, original(item), data); };
+			cb.title = function(items, data) { return title.call(this, items.map(original), data); };
+		},
+		afterDraw: function(chart) {
+			var ctx = chart.ctx, a = chart.chartArea;
+			Chart.helpers.each(chart.scales, function(scale) {
+				(scale.options.breaks || []).forEach(function(b) {
+					var p = scale.getPixelForValue(b.at), h = scale.isHorizontal();
+					var lo = h ? a.top : a.left, hi = h ? a.bottom : a.right;
+					if (p < (h ? a.left : a.top) || p > (h ? a.right : a.bottom)) { return; }
+					var zig = function(o) {
+						var pts = [];
+						for (var q = lo, i = 0; q < hi + 4; q += 4, i++) {
+							var d = p + o + (i % 2 ? 2 : -2);
+							pts.push(h ? [d, Math.min(q, hi)] : [Math.min(q, hi), d]);
+						}
+						return pts;
+					};
+					var one = zig(-3), two = zig(3).reverse();
+					ctx.save();
+					ctx.beginPath();
+					one.concat(two).forEach(function(q, i) { if (i) { ctx.lineTo(q[0], q[1]); } else { ctx.moveTo(q[0], q[1]); } });
+					ctx.closePath();
+					ctx.fillStyle = 'white';
+					ctx.fill();
+					ctx.strokeStyle = 'rgba(0, 0, 0, 0.5)';
+					ctx.lineWidth = 1;
+					[one, two].forEach(function(z) {
+						ctx.beginPath();
+						z.forEach(function(q, i) { if (i) { ctx.lineTo(q[0], q[1]); } else { ctx.moveTo(q[0], q[1]); } });
+						ctx.stroke();
+					});
+					ctx.restore();
+				});
+			});
+		}
+	});
+})();
+`
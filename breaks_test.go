@@ -0,0 +1,77 @@
+package chartjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBreaks(t *testing.T) {
+	a := Axis{Type: Linear, Breaks: []Break{{From: 1000, To: 5000}, {From: 40, To: 900}}}
+	for _, c := range []struct{ v, want float64 }{{10, 10}, {950, 90}, {5100, 240}} {
+		if got := a.Transform(c.v); got != c.want {
+			t.Fatalf("expected %v to be placed at %v, got %v", c.v, c.want, got)
+		}
+		if got := a.untransform(c.want); got != c.v {
+			t.Fatalf("expected %v to be restored to %v, got %v", c.want, c.v, got)
+		}
+	}
+	if v := a.Transform(100); !math.IsNaN(v) {
+		t.Fatalf("expected a value within a break to be dropped, got %v", v)
+	}
+	_, labels := a.Ticks(0, 240)
+	if got := strings.Join(labels, " "); got != "0 950 5050 5100" {
+		t.Fatalf("unexpected ticks: %s", got)
+	}
+}
+
+func TestBreaksJSON(t *testing.T) {
+	c := Chart{Type: Line}
+	c.AddDataset(Dataset{Data: XY{X: []float64{1, 2, 3}, Y: []float64{10, 100, 1050}}})
+	c.AddYAxis(Axis{Type: Linear, Breaks: []Break{{From: 50, To: 1000}}, Tick: &Tick{Max: 1100}})
+
+	b, warnings, err := c.Marshal()
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Dropped != 1 {
+		t.Fatalf("expected a warning for the value within the break, got %v", warnings)
+	}
+	for _, want := range []string{`"y":null`, `"y":100.00`, `"breaks":[{"from":50,"to":1000,"at":50}]`, `"ticks":{"max":150}`} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("expected %s in %s", want, b)
+		}
+	}
+	if !json.Valid(b) {
+		t.Fatalf("invalid json: %s", b)
+	}
+	var buf bytes.Buffer
+	if err := c.SaveHTML(&buf, nil); err != nil {
+		t.Fatalf("error saving chart: %+v", err)
+	}
+	if !strings.Contains(buf.String(), "Chart.goChartjs.breaks") {
+		t.Fatalf("expected the breaks plugin in html")
+	}
+
+	// a limit within a break is placed where the break is drawn.
+	c.Options.Scales.YAxes[0].Tick = &Tick{Min: 20, Max: 500}
+	if b, err = json.Marshal(c); err != nil || !strings.Contains(string(b), `"ticks":{"min":20,"max":50}`) {
+		t.Fatalf("expected the max to be moved to the break in %s (%v)", b, err)
+	}
+
+	c.Options.Scales.YAxes[0].Breaks = append(c.Options.Scales.YAxes[0].Breaks, Break{From: 900, To: 2000})
+	if _, err := json.Marshal(c); err == nil {
+		t.Fatalf("expected error with overlapping breaks")
+	}
+	c.Options.Scales.YAxes[0].Breaks = []Break{{From: 2, To: 1}}
+	if _, err := json.Marshal(c); err == nil {
+		t.Fatalf("expected error with an empty break")
+	}
+	c.Options.Scales.YAxes[0].Type = Log
+	c.Options.Scales.YAxes[0].Breaks = []Break{{From: 1, To: 2}}
+	if _, err := json.Marshal(c); err == nil {
+		t.Fatalf("expected error with breaks on a log axis")
+	}
+}
@@ -15,9 +15,15 @@ var True = types.True
 var False = types.False
 
 var chartTypes = [...]string{
+	"",
 	"line",
 	"bar",
 	"bubble",
+	"boxplot",
+	"violin",
+	"matrix",
+	"radar",
+	"polarArea",
 }
 
 type chartType int
@@ -27,12 +33,25 @@ func (c chartType) MarshalJSON() ([]byte, error) {
 }
 
 const (
+	// the zero chartType is unset. A Chart chooses its type from its datasets and a dataset
+	// takes the type of its Chart.
+	_ chartType = iota
 	// Line is a "line" plot
-	Line chartType = iota
+	Line
 	// Bar is a "bar" plot
 	Bar
 	// Bubble is a "bubble" plot
 	Bubble
+	// BoxPlot is a "boxplot" plot. It requires Dataset.Samples.
+	BoxPlot
+	// Violin is a "violin" plot. It requires Dataset.Samples.
+	Violin
+	// Heatmap is a "matrix" plot. It requires Dataset.Matrix.
+	Heatmap
+	// Radar is a "radar" plot of a single set of Values for each of the Labels.
+	Radar
+	// PolarArea is a "polarArea" plot of a single set of Values for each of the Labels.
+	PolarArea
 )
 
 type interpMode int
@@ -53,6 +72,48 @@ func (m interpMode) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + interpModes[m] + `"`), nil
 }
 
+// capStyle determines how the ends of a line are drawn.
+type capStyle int
+
+const (
+	_ capStyle = iota
+	CapButt
+	CapRound
+	CapSquare
+)
+
+var capStyles = [...]string{
+	"",
+	"butt",
+	"round",
+	"square",
+}
+
+func (c capStyle) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + capStyles[c] + `"`), nil
+}
+
+// joinStyle determines how the segments of a line are joined.
+type joinStyle int
+
+const (
+	_ joinStyle = iota
+	JoinBevel
+	JoinRound
+	JoinMiter
+)
+
+var joinStyles = [...]string{
+	"",
+	"bevel",
+	"round",
+	"miter",
+}
+
+func (j joinStyle) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + joinStyles[j] + `"`), nil
+}
+
 // XFloatFormat determines how many decimal places are sent in the JSON for X values.
 var XFloatFormat = "%.2f"
 
@@ -124,7 +185,7 @@ func marshalValuesJSON(v Values, xformat, yformat string) ([]byte, error) {
 			if i > 0 {
 				buf.WriteRune(',')
 			}
-			_, err := buf.WriteString(fmt.Sprintf(xformat, x))
+			_, err := buf.WriteString(formatFloat(xformat, x))
 			if err != nil {
 				return nil, err
 			}
@@ -170,17 +231,51 @@ func (s shape) MarshalJSON() ([]byte, error) {
 
 // Dataset wraps the "dataset" JSON
 type Dataset struct {
-	Data            Values      `json:"-"`
-	Type            chartType   `json:"type,omitempty"`
+	Data Values `json:"-"`
+	// Samples holds the data for BoxPlot and Violin datasets and is used instead of Data.
+	Samples Samples `json:"-"`
+	// Whiskers determines the extent of the whiskers of a BoxPlot.
+	Whiskers whiskerMode `json:"-"`
+	// Matrix holds the data for Heatmap datasets and is used instead of Data.
+	// It may also be drawn as a Bubble dataset of squares when the matrix plugin isn't wanted.
+	Matrix Matrix `json:"-"`
+	// ColorScale determines the colors of the cells of a Matrix.
+	ColorScale *ColorScale `json:"-"`
+
+	// Type draws the dataset as a different type than its Chart. Line, Bar, Bubble, BoxPlot and
+	// Violin datasets may be mixed on the same axes; a Heatmap may not be mixed.
+	Type chartType `json:"type,omitempty"`
+	// Order is the drawing order. Datasets with a lower Order are drawn on top.
+	Order int `json:"order,omitempty"`
+	// Stack groups the Bar datasets that are stacked on each other when the axes are Stacked.
+	Stack string `json:"stack,omitempty"`
+
 	BackgroundColor *types.RGBA `json:"backgroundColor,omitempty"`
 	// BorderColor is the color of the line.
 	BorderColor *types.RGBA `json:"borderColor,omitempty"`
+	// BackgroundColors and BorderColors give a color to each bar or point in place of
+	// BackgroundColor and BorderColor. They must be the length of the Data. See SignColors.
+	BackgroundColors []types.RGBA `json:"-"`
+	BorderColors     []types.RGBA `json:"-"`
+	// HoverBackgroundColor and HoverBorderColor are used when the mouse is over a bar or point.
+	HoverBackgroundColor *types.RGBA `json:"hoverBackgroundColor,omitempty"`
+	HoverBorderColor     *types.RGBA `json:"hoverBorderColor,omitempty"`
+	// BorderSkipped is the side of each bar that has no border.
+	BorderSkipped edge `json:"borderSkipped,omitempty"`
+	// BarOptions sizes the bars of a Bar dataset. Its fields are sent in the dataset.
+	*BarOptions
 	// BorderWidth is the width of the line.
 	BorderWidth float64 `json:"borderWidth"`
+	// BorderDash is the lengths of alternating dashes and gaps of the line, e.g. {5, 5}.
+	BorderDash       []float64 `json:"borderDash,omitempty"`
+	BorderDashOffset float64   `json:"borderDashOffset,omitempty"`
+	BorderCapStyle   capStyle  `json:"borderCapStyle,omitempty"`
+	BorderJoinStyle  joinStyle `json:"borderJoinStyle,omitempty"`
 
 	// Label indicates the name of the dataset to be shown in the legend.
-	Label string     `json:"label,omitempty"`
-	Fill  types.Bool `json:"fill,omitempty"`
+	Label string `json:"label,omitempty"`
+	// Fill is how the area under a line is filled. Use FillBool to convert True or False.
+	Fill FillMode `json:"fill,omitempty"`
 
 	// SteppedLine of true means dont interpolate and ignore line tension.
 	SteppedLine            types.Bool  `json:"steppedLine,omitempty"`
@@ -203,10 +298,16 @@ type Dataset struct {
 	XAxisID string `json:"xAxisID,omitempty"`
 	YAxisID string `json:"yAxisID,omitempty"`
 
+	// ErrorMode determines how the uncertainty is drawn when Data implements ErrorValues.
+	ErrorMode errorMode `json:"-"`
+
 	// set the formatter for the data, e.g. "%.2f"
 	// these are not exported in the json, just used to determine the decimals of precision to show
 	XFloatFormat string `json:"-"`
 	YFloatFormat string `json:"-"`
+
+	// band marks the datasets that draw an ErrorBand so that they are left out of the legend.
+	band bool
 }
 
 // MarshalJSON implements json.Marshaler interface.
@@ -219,7 +320,26 @@ func (d Dataset) MarshalJSON() ([]byte, error) {
 		yf = YFloatFormat
 	}
 
-	o, err := marshalValuesJSON(d.Data, xf, yf)
+	var o, extra []byte
+	var err error
+	if d.Matrix != nil {
+		o, extra, err = marshalMatrixJSON(d.Matrix, d.ColorScale, d.Type == Bubble, yf)
+		// the colors of the cells are sent instead.
+		d.BackgroundColor = nil
+	} else if d.Samples != nil {
+		o, err = marshalSamplesJSON(d.Samples, d.Type, d.Whiskers, yf)
+	} else {
+		o, err = marshalValuesJSON(d.Data, xf, yf)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if d.BackgroundColors != nil {
+		d.BackgroundColor = nil
+	}
+	if d.BorderColors != nil {
+		d.BorderColor = nil
+	}
 	// avoid recursion by creating an alias.
 	type alias Dataset
 	buf, err := json.Marshal(alias(d))
@@ -230,6 +350,40 @@ func (d Dataset) MarshalJSON() ([]byte, error) {
 	if len(buf) > 0 {
 		buf[len(buf)-1] = ','
 	}
+	buf = append(buf, extra...)
+	if d.BackgroundColors != nil || d.BorderColors != nil {
+		n := 0
+		if d.Data != nil {
+			if n = len(d.Data.Ys()); n == 0 {
+				n = len(d.Data.Xs())
+			}
+		}
+		for _, f := range []struct {
+			name   string
+			colors []types.RGBA
+		}{{"backgroundColor", d.BackgroundColors}, {"borderColor", d.BorderColors}} {
+			if f.colors == nil {
+				continue
+			}
+			c, err := marshalColorsJSON(f.name, f.colors, n)
+			if err != nil {
+				return nil, err
+			}
+			buf = append(buf, c...)
+		}
+	}
+	if ev, ok := d.Data.(ErrorValues); ok && d.ErrorMode == ErrorBars {
+		e, err := marshalErrorBarsJSON(ev, xf, yf)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, []byte(`"errorBars":`)...)
+		buf = append(buf, e...)
+		buf = append(buf, ',')
+	}
+	if d.band {
+		buf = append(buf, []byte(`"errorBand":true,`)...)
+	}
 	buf = append(buf, []byte(`"data":`)...)
 	buf = append(buf, o...)
 	buf = append(buf, '}')
@@ -240,6 +394,45 @@ func (d Dataset) MarshalJSON() ([]byte, error) {
 type Data struct {
 	Datasets []Dataset `json:"datasets"`
 	Labels   []string  `json:"labels"`
+	// XLabels and YLabels are used by category axes in place of Labels.
+	XLabels []string `json:"xLabels,omitempty"`
+	YLabels []string `json:"yLabels,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Datasets drawn with ErrorBand are expanded to include their low and high bands.
+func (d Data) MarshalJSON() ([]byte, error) {
+	// pos holds the index of each dataset after the bands are added.
+	pos := make([]int, len(d.Datasets))
+	n := 0
+	for i, ds := range d.Datasets {
+		if _, ok := ds.Data.(ErrorValues); ok && ds.ErrorMode == ErrorBand {
+			n += 2
+		}
+		pos[i] = n
+		n++
+	}
+	filled, err := fillTargets(d.Datasets, pos)
+	if err != nil {
+		return nil, err
+	}
+	datasets := make([]interface{}, 0, n)
+	for _, ds := range filled {
+		if ev, ok := ds.Data.(ErrorValues); ok && ds.ErrorMode == ErrorBand {
+			lo, hi, err := bands(ds, ev)
+			if err != nil {
+				return nil, err
+			}
+			datasets = append(datasets, lo, hi)
+		}
+		datasets = append(datasets, ds)
+	}
+	return json.Marshal(struct {
+		Datasets []interface{} `json:"datasets"`
+		Labels   []string      `json:"labels"`
+		XLabels  []string      `json:"xLabels,omitempty"`
+		YLabels  []string      `json:"yLabels,omitempty"`
+	}{datasets, d.Labels, d.XLabels, d.YLabels})
 }
 
 type axisType int
@@ -250,6 +443,7 @@ var axisTypes = []string{
 	"logarithmic",
 	"time",
 	"radialLinear",
+	"linear",
 }
 
 const (
@@ -262,8 +456,11 @@ const (
 	Log
 	// Time axis
 	Time
-	// Radial axis
+	// Radial is the type of a RadialScale. It can't be used in Options.Scales.
 	Radial
+	// Symlog is a linear axis of sign(v)*log10(1+|v|/c), computed in Go, so that values that
+	// span zero can be shown as on a Log axis. The ticks are labelled with the original values.
+	Symlog
 )
 
 func (t axisType) MarshalJSON() ([]byte, error) {
@@ -297,12 +494,22 @@ func (p axisPosition) MarshalJSON() ([]byte, error) {
 
 // Axis corresponds to 'scale' in chart.js lingo.
 type Axis struct {
-	Type      axisType     `json:"type"`
-	Position  axisPosition `json:"position,omitempty"`
-	Label     string       `json:"label,omitempty"`
-	ID        string       `json:"id,omitempty"`
-	GridLines types.Bool   `json:"gridLine,omitempty"`
-	Stacked   types.Bool   `json:"stacked,omitempty"`
+	Type     axisType     `json:"type"`
+	Position axisPosition `json:"position,omitempty"`
+	Label    string       `json:"label,omitempty"`
+	ID       string       `json:"id,omitempty"`
+	// GridLines styles the grid lines, border and tick marks of the axis.
+	GridLines *GridLineOptions `json:"gridLines,omitempty"`
+	Stacked   types.Bool       `json:"stacked,omitempty"`
+	// Offset adds space at both edges so that categories are not cut off.
+	Offset types.Bool `json:"offset,omitempty"`
+	// Bar sizes the bars of the Bar datasets on an x axis that don't have their own BarOptions.
+	// It is sent with the datasets, as Chart.js 2.9 expects, and on the axis for earlier versions.
+	Bar *BarOptions `json:"-"`
+	// Log sets the ticks of a Log or Symlog axis and how a Log axis shows values that aren't positive.
+	Log *LogOptions `json:"-"`
+	// Breaks are ranges of values left out of a Linear axis.
+	Breaks []Break `json:"-"`
 
 	// Bool differentiates between false and empty by use of pointer.
 	Display    types.Bool  `json:"display,omitempty"`
@@ -310,6 +517,92 @@ type Axis struct {
 	Tick       *Tick       `json:"ticks,omitempty"`
 }
 
+// GridLineOptions corresponds to the gridLines of an axis. The zero line is the grid line at 0.
+type GridLineOptions struct {
+	// Display of False hides the grid lines but not the border or tick marks.
+	Display          types.Bool  `json:"display,omitempty"`
+	Color            *types.RGBA `json:"color,omitempty"`
+	LineWidth        float64     `json:"lineWidth,omitempty"`
+	BorderDash       []float64   `json:"borderDash,omitempty"`
+	BorderDashOffset float64     `json:"borderDashOffset,omitempty"`
+	// DrawBorder of False hides the line along the edge of the chart.
+	DrawBorder types.Bool `json:"drawBorder,omitempty"`
+	// DrawOnChartArea of False draws the tick marks without the lines across the chart.
+	DrawOnChartArea types.Bool `json:"drawOnChartArea,omitempty"`
+	DrawTicks       types.Bool `json:"drawTicks,omitempty"`
+
+	// Circular draws the grid lines of a RadialScale on a Radar chart as circles.
+	Circular types.Bool `json:"circular,omitempty"`
+
+	ZeroLineColor            *types.RGBA `json:"zeroLineColor,omitempty"`
+	ZeroLineWidth            float64     `json:"zeroLineWidth,omitempty"`
+	ZeroLineBorderDash       []float64   `json:"zeroLineBorderDash,omitempty"`
+	ZeroLineBorderDashOffset float64     `json:"zeroLineBorderDashOffset,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (a Axis) MarshalJSON() ([]byte, error) {
+	if a.Tick != nil && a.transforms() {
+		// the limits are placed on the axis like the data.
+		t := *a.Tick
+		for _, v := range []*float64{&t.Min, &t.Max} {
+			f := a.Transform(*v)
+			for _, r := range a.breaks() {
+				if *v > r.From && *v < r.To {
+					// a limit within a break is moved to where the break is drawn.
+					f = a.Transform(r.From)
+				}
+			}
+			if *v != 0 && !math.IsNaN(f) {
+				*v = f
+			}
+		}
+		a.Tick = &t
+	}
+	// avoid recursion by creating an alias.
+	type alias Axis
+	buf, err := json.Marshal(alias(a))
+	if err != nil {
+		return nil, err
+	}
+	var extra []byte
+	if a.Bar != nil {
+		b, err := json.Marshal(a.Bar)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > 2 {
+			extra = append(extra, b[1:len(b)-1]...)
+			extra = append(extra, ',')
+		}
+	}
+	if o, ok := a.logOptions(); ok {
+		var c, p float64
+		if a.Type == Symlog {
+			c = o.Constant
+		} else if o.Safety == LogPseudocount {
+			p = o.Pseudocount
+		}
+		extra = append(extra, fmt.Sprintf(`"logTicks":{"minor":%t,"symlog":%g,"pseudocount":%g},`, o.Minor, c, p)...)
+	}
+	if b := a.breaks(); b != nil {
+		extra = append(extra, `"breaks":[`...)
+		for i, r := range b {
+			if i > 0 {
+				extra = append(extra, ',')
+			}
+			extra = append(extra, fmt.Sprintf(`{"from":%g,"to":%g,"at":%g}`, r.From, r.To, removeBreaks(b, r.From))...)
+		}
+		extra = append(extra, "],"...)
+	}
+	if extra == nil {
+		return buf, nil
+	}
+	buf[len(buf)-1] = ','
+	buf = append(buf, extra[:len(extra)-1]...)
+	return append(buf, '}'), nil
+}
+
 // Tick lets us set the range of the data.
 type Tick struct {
 	Min         float64    `json:"min,omitempty"`
@@ -361,9 +654,11 @@ type Title struct {
 // Options wraps the chartjs "options"
 type Options struct {
 	Option
-	Scales  Axes     `json:"scales,omitempty"`
-	Legend  *Legend  `json:"legend,omitempty"`
-	Tooltip *Tooltip `json:"tooltips,omitempty"`
+	Scales Axes `json:"scales,omitempty"`
+	// Scale is the scale of Radar and PolarArea charts, which have no Scales.
+	Scale   *RadialScale `json:"scale,omitempty"`
+	Legend  *Legend      `json:"legend,omitempty"`
+	Tooltip *Tooltip     `json:"tooltips,omitempty"`
 }
 
 // Tooltip wraps chartjs "tooltips".
@@ -386,6 +681,165 @@ type Chart struct {
 	Label   string    `json:"label,omitempty"`
 	Data    Data      `json:"data,omitempty"`
 	Options Options   `json:"options,omitempty"`
+
+	// ID is the id of the canvas element. By default it is the prefix followed by the index of the chart.
+	ID string `json:"-"`
+}
+
+// ResolvedType returns Type or, if it is unset, the type chosen from the datasets: a Heatmap or
+// BoxPlot (or Violin) for datasets with a Matrix or Samples, else Bar if any dataset is a Bar so
+// that the x-axis holds categories, else the first Type set on a dataset, else Line.
+func (c Chart) ResolvedType() chartType {
+	if c.Type != 0 {
+		return c.Type
+	}
+	var samples, first chartType
+	bar := false
+	for _, d := range c.Data.Datasets {
+		switch {
+		case d.Matrix != nil && (d.Type == 0 || d.Type == Heatmap):
+			return Heatmap
+		case d.Samples != nil && samples == 0:
+			samples = d.Type
+			if samples == 0 {
+				samples = BoxPlot
+			}
+		case d.Type == Bar:
+			bar = true
+		}
+		if first == 0 {
+			first = d.Type
+		}
+	}
+	switch {
+	case samples != 0:
+		return samples
+	case bar:
+		return Bar
+	case first != 0:
+		return first
+	}
+	return Line
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (c Chart) MarshalJSON() ([]byte, error) {
+	b, _, err := c.Marshal()
+	return b, err
+}
+
+// Marshal returns the JSON of the chart along with a Warning for each dataset that had values
+// dropped from a Log axis with LogDrop.
+func (c Chart) Marshal() ([]byte, []Warning, error) {
+	var warnings []Warning
+	c.Type = c.ResolvedType()
+	if err := c.validRadial(); err != nil {
+		return nil, nil, err
+	}
+	ids := make(map[string]bool)
+	for _, a := range append(append([]Axis{}, c.Options.Scales.XAxes...), c.Options.Scales.YAxes...) {
+		if err := a.validBreaks(); err != nil {
+			return nil, nil, err
+		}
+		if a.ID != "" && ids[a.ID] {
+			return nil, nil, fmt.Errorf("chart: axis ID %q is used more than once", a.ID)
+		}
+		ids[a.ID] = true
+	}
+	datasets := make([]Dataset, len(c.Data.Datasets))
+	for i, d := range c.Data.Datasets {
+		t := d.Type
+		if t == 0 {
+			t = c.Type
+		}
+		if (c.Type == Heatmap) != (t == Heatmap) {
+			return nil, nil, fmt.Errorf("chart: dataset %d: a Heatmap can't be mixed with other types", i)
+		}
+		if c.Type.radial() != t.radial() {
+			return nil, nil, fmt.Errorf("chart: dataset %d: Radar and PolarArea charts can't be mixed with other types", i)
+		}
+		if t.radial() && d.Data != nil && len(d.Data.Xs()) > 0 && len(d.Data.Ys()) > 0 {
+			return nil, nil, fmt.Errorf("chart: dataset %d: Radar and PolarArea datasets have a single set of values", i)
+		}
+		if (d.Samples != nil) != (t == BoxPlot || t == Violin) {
+			return nil, nil, fmt.Errorf("chart: dataset %d: only BoxPlot and Violin datasets have Samples", i)
+		}
+		if !hasAxis(c.Options.Scales.XAxes, d.XAxisID, "x-axis-0") {
+			return nil, nil, fmt.Errorf("chart: dataset %d: no x-axis %q", i, d.XAxisID)
+		}
+		if !hasAxis(c.Options.Scales.YAxes, d.YAxisID, "y-axis-0") {
+			return nil, nil, fmt.Errorf("chart: dataset %d: no y-axis %q", i, d.YAxisID)
+		}
+		x, y := findAxis(c.Options.Scales.XAxes, d.XAxisID), findAxis(c.Options.Scales.YAxes, d.YAxisID)
+		if t == Bar {
+			d.BarOptions = d.BarOptions.merge(x.Bar)
+		}
+		if d.Data != nil {
+			var xd, yd int
+			d.Data, xd, yd = transformValues(d.Data, x, y)
+			if xd > 0 {
+				warnings = append(warnings, Warning{Dataset: i, Axis: x.ID, Dropped: xd})
+			}
+			if yd > 0 {
+				warnings = append(warnings, Warning{Dataset: i, Axis: y.ID, Dropped: yd})
+			}
+		}
+		if d.Samples != nil {
+			// BoxPlot and Violin datasets are sent differently so they must know their type.
+			d.Type = t
+		}
+		if d.Matrix != nil {
+			d.Type = t
+			if d.Type != Heatmap && d.Type != Bubble {
+				return nil, nil, fmt.Errorf("chart: dataset %d has a Matrix but is not a Heatmap or Bubble", i)
+			}
+			// cells are placed by their row and column labels on category axes.
+			rows, cols := d.Matrix.Dims()
+			if len(c.Data.XLabels) == 0 {
+				c.Data.XLabels = labels(d.Matrix.ColLabels(), cols)
+			}
+			if len(c.Data.YLabels) == 0 {
+				c.Data.YLabels = labels(d.Matrix.RowLabels(), rows)
+			}
+			if len(c.Options.Scales.XAxes) == 0 {
+				c.Options.Scales.XAxes = []Axis{{Type: Category, Position: Bottom, Offset: True}}
+			}
+			if len(c.Options.Scales.YAxes) == 0 {
+				c.Options.Scales.YAxes = []Axis{{Type: Category, Position: Left, Offset: True}}
+			}
+		}
+		datasets[i] = d
+	}
+	c.Data.Datasets = datasets
+	// avoid recursion by creating an alias.
+	type alias Chart
+	b, err := json.Marshal(alias(c))
+	return b, warnings, err
+}
+
+// findAxis returns the axis with the given ID, or the first one if id is empty. If there is none,
+// it returns an empty Axis.
+func findAxis(axes []Axis, id string) Axis {
+	for _, a := range axes {
+		if id == "" || a.ID == id {
+			return a
+		}
+	}
+	return Axis{}
+}
+
+// hasAxis returns true if id is empty or is the ID of one of the axes. When there are none, the
+// axis that Chart.js makes has the ID def.
+func hasAxis(axes []Axis, id, def string) bool {
+	if id == "" || (len(axes) == 0 && id == def) {
+		return true
+	}
+	for _, a := range axes {
+		if a.ID == id {
+			return true
+		}
+	}
+	return false
 }
 
 // AddDataset adds a dataset to the chart.
@@ -393,14 +847,65 @@ func (c *Chart) AddDataset(d Dataset) {
 	c.Data.Datasets = append(c.Data.Datasets, d)
 }
 
+// AddDatasetOnNewAxis adds the axis and a dataset that is drawn on it, and returns the ID of the
+// axis. The axis is an x-axis if it is at the Top or Bottom and a y-axis otherwise; a y-axis
+// without a Position is placed on the Right if the chart already has one.
+func (c *Chart) AddDatasetOnNewAxis(d Dataset, a Axis) (string, error) {
+	var id string
+	var err error
+	if a.Position == Top || a.Position == Bottom {
+		id, err = c.AddXAxis(a)
+		d.XAxisID = id
+	} else {
+		if a.Position == 0 && len(c.Options.Scales.YAxes) > 0 {
+			a.Position = Right
+		}
+		id, err = c.AddYAxis(a)
+		d.YAxisID = id
+	}
+	if err != nil {
+		return "", err
+	}
+	c.AddDataset(d)
+	return id, nil
+}
+
+// Axis returns the x- or y-axis with the given ID so that it can be changed, or nil if there is
+// none. The pointer is only valid until another axis is added.
+func (c *Chart) Axis(id string) *Axis {
+	for _, axes := range [][]Axis{c.Options.Scales.XAxes, c.Options.Scales.YAxes} {
+		for i := range axes {
+			if axes[i].ID == id {
+				return &axes[i]
+			}
+		}
+	}
+	return nil
+}
+
+// newAxisID returns the ID for an axis without one: the prefix followed by the number of axes
+// before it, or a larger number if that is already used.
+func (c *Chart) newAxisID(prefix string, n int) string {
+	for {
+		id := fmt.Sprintf("%s%d", prefix, n)
+		if c.Axis(id) == nil {
+			return id
+		}
+		n++
+	}
+}
+
 // AddXAxis adds an x-axis to the chart and returns the ID of the added axis.
 func (c *Chart) AddXAxis(x Axis) (string, error) {
 	if x.ID == "" {
-		x.ID = fmt.Sprintf("xaxis%d", len(c.Options.Scales.XAxes))
+		x.ID = c.newAxisID("xaxis", len(c.Options.Scales.XAxes))
 	}
 	if x.Position == Left || x.Position == Right {
 		return "", fmt.Errorf("chart: added x-axis to left or right")
 	}
+	if c.Axis(x.ID) != nil {
+		return "", fmt.Errorf("chart: axis ID %q is already used", x.ID)
+	}
 	c.Options.Scales.XAxes = append(c.Options.Scales.XAxes, x)
 	return x.ID, nil
 }
@@ -408,11 +913,14 @@ func (c *Chart) AddXAxis(x Axis) (string, error) {
 // AddYAxis adds an y-axis to the chart and return the ID of the added axis.
 func (c *Chart) AddYAxis(y Axis) (string, error) {
 	if y.ID == "" {
-		y.ID = fmt.Sprintf("yaxis%d", len(c.Options.Scales.YAxes))
+		y.ID = c.newAxisID("yaxis", len(c.Options.Scales.YAxes))
 	}
 	if y.Position == Top || y.Position == Bottom {
 		return "", fmt.Errorf("chart: added y-axis to top or bottom")
 	}
+	if c.Axis(y.ID) != nil {
+		return "", fmt.Errorf("chart: axis ID %q is already used", y.ID)
+	}
 	c.Options.Scales.YAxes = append(c.Options.Scales.YAxes, y)
 	return y.ID, nil
 }
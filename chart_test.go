@@ -6,38 +6,23 @@ import (
 	"math"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/brentp/go-chartjs/types"
 )
 
-type xy struct {
-	x []float64
-	y []float64
-	r []float64
-}
-
-func (v xy) Xs() []float64 {
-	return v.x
-}
-func (v xy) Ys() []float64 {
-	return v.y
-}
-func (v xy) Rs() []float64 {
-	return v.r
-}
-
 func TestLine(t *testing.T) {
 
 	//var axes Axes
 	//axes.AddY(Axis{Type: Linear, Position: Bottom})
 	//axes.AddY(Axis{Type: Linear, Position: Left})
 
-	var xys xy
+	var xys XYR
 	for i := 0; i < 10; i++ {
-		xys.x = append(xys.x, float64(i))
-		xys.y = append(xys.y, float64(i))
-		xys.r = append(xys.r, float64(i))
+		xys.X = append(xys.X, float64(i))
+		xys.Y = append(xys.Y, float64(i))
+		xys.R = append(xys.R, float64(i))
 	}
 
 	d := Dataset{Data: xys, BackgroundColor: &types.RGBA{0, 255, 0, 200}, Label: "HHIHIHI"}
@@ -65,10 +50,10 @@ func TestBar(t *testing.T) {
 	//axes.AddY(Axis{Type: Linear, Position: Bottom})
 	//axes.AddY(Axis{Type: Linear, Position: Left})
 
-	var xs xy
+	var xs Ys
 	var labels []string
 	for i := 0; i < 10; i++ {
-		xs.x = append(xs.x, float64(i))
+		xs = append(xs, float64(i))
 		labels = append(labels, strconv.Itoa(i))
 	}
 	d := Dataset{Data: xs, BackgroundColor: &types.RGBA{0, 255, 0, 200}}
@@ -91,13 +76,13 @@ func TestBar(t *testing.T) {
 
 func TestHTML(t *testing.T) {
 
-	var xys xy
+	var xys XYR
 	for i := float64(0); i < 9; i += 0.05 {
-		xys.x = append(xys.x, float64(i))
-		xys.y = append(xys.y, math.Sin(float64(i)))
-		xys.r = append(xys.r, float64(i))
+		xys.X = append(xys.X, float64(i))
+		xys.Y = append(xys.Y, math.Sin(float64(i)))
+		xys.R = append(xys.R, float64(i))
 	}
-	fmt.Println(len(xys.x))
+	fmt.Println(len(xys.X))
 
 	d := Dataset{Data: xys, BackgroundColor: &types.RGBA{0, 255, 0, 200}, Label: "sin(x)"}
 
@@ -123,16 +108,16 @@ func TestHTML(t *testing.T) {
 }
 
 func TestMultipleCharts(t *testing.T) {
-	var xys1 xy
-	var xys2 xy
+	var xys1 XY
+	var xys2 XY
 
 	for i := float64(0); i < 9; i += 0.1 {
-		xys1.x = append(xys1.x, float64(i))
-		xys2.x = append(xys2.x, float64(i))
+		xys1.X = append(xys1.X, float64(i))
+		xys2.X = append(xys2.X, float64(i))
 
-		xys1.y = append(xys1.y, math.Sin(float64(i)))
+		xys1.Y = append(xys1.Y, math.Sin(float64(i)))
 
-		xys2.y = append(xys2.y, 2*math.Cos(float64(i)))
+		xys2.Y = append(xys2.Y, 2*math.Cos(float64(i)))
 
 	}
 
@@ -144,10 +129,10 @@ func TestMultipleCharts(t *testing.T) {
 		&types.RGBA{230, 138, 195, 220},
 	}
 
-	d1 := Dataset{Data: xys1, BorderColor: colors[0], Label: "sin(x)", Fill: types.False,
+	d1 := Dataset{Data: xys1, BorderColor: colors[0], Label: "sin(x)", Fill: FillBool(types.False),
 		PointRadius: 10, PointBorderWidth: 4, BackgroundColor: colors[1]}
 
-	d2 := Dataset{Data: xys2, BorderWidth: 8, BorderColor: colors[2], Label: "2 * cos(x)", Fill: types.False}
+	d2 := Dataset{Data: xys2, BorderWidth: 8, BorderColor: colors[2], Label: "2 * cos(x)", Fill: FillBool(types.False)}
 
 	chart := Chart{Type: Line, Label: "test-chart"}
 	chart.AddXAxis(Axis{Type: Linear, Position: Bottom, ScaleLabel: &ScaleLabel{FontSize: 22, LabelString: "X", Display: types.True}})
@@ -178,11 +163,11 @@ func TestMultipleCharts(t *testing.T) {
 
 func TestAnno(t *testing.T) {
 
-	var xys xy
+	var xys XYR
 	for i := float64(0); i < 9; i += 0.05 {
-		xys.x = append(xys.x, float64(i))
-		xys.y = append(xys.y, math.Sin(float64(i)))
-		xys.r = append(xys.r, float64(i))
+		xys.X = append(xys.X, float64(i))
+		xys.Y = append(xys.Y, math.Sin(float64(i)))
+		xys.R = append(xys.R, float64(i))
 	}
 
 	d := Dataset{Data: xys, BackgroundColor: &types.RGBA{0, 255, 0, 200}, Label: "sin(x)"}
@@ -207,3 +192,131 @@ func TestAnno(t *testing.T) {
 	}
 	wtr.Close()
 }
+
+func TestMixed(t *testing.T) {
+	vals := XY{X: []float64{1, 2, 3}, Y: []float64{2, 3, 4}}
+
+	// a line on bars, with the chart type chosen from the datasets.
+	var c Chart
+	c.Data.Labels = []string{"a", "b", "c"}
+	c.AddDataset(Dataset{Data: vals, Type: Bar, Stack: "s1"})
+	c.AddDataset(Dataset{Data: vals, Type: Line, Order: -1})
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling line on bar: %+v", err)
+	}
+	s := string(b)
+	for _, want := range []string{`{"type":"bar","data"`, `"type":"line","order":-1`, `"type":"bar","stack":"s1"`} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("expected %q in %s", want, s)
+		}
+	}
+
+	// bubbles on a line.
+	c = Chart{Type: Line}
+	c.AddDataset(Dataset{Data: vals})
+	c.AddDataset(Dataset{Data: XYR{X: []float64{1}, Y: []float64{2}, R: []float64{5}}, Type: Bubble})
+	if b, err = json.Marshal(c); err != nil {
+		t.Fatalf("error marshaling bubble on line: %+v", err)
+	}
+	if s = string(b); !strings.HasPrefix(s, `{"type":"line"`) || !strings.Contains(s, `"type":"bubble"`) {
+		t.Fatalf("unexpected bubble on line: %s", s)
+	}
+
+	for want, c := range map[chartType]Chart{
+		Line:    {},
+		Bubble:  {Data: Data{Datasets: []Dataset{{}, {Type: Bubble}}}},
+		Bar:     {Data: Data{Datasets: []Dataset{{Type: Line}, {Type: Bar}}}},
+		Violin:  {Data: Data{Datasets: []Dataset{{Type: Line}, {Samples: RawSamples{{1}}, Type: Violin}}}},
+		BoxPlot: {Data: Data{Datasets: []Dataset{{Samples: RawSamples{{1}}}}}},
+		Heatmap: {Data: Data{Datasets: []Dataset{{Matrix: LabeledMatrix{Data: [][]float64{{1}}}}}}},
+	} {
+		if got := c.ResolvedType(); got != want {
+			t.Fatalf("expected %s, got %s", chartTypes[want], chartTypes[got])
+		}
+	}
+
+	for _, c := range []Chart{
+		{Type: Heatmap, Data: Data{Datasets: []Dataset{{Matrix: LabeledMatrix{Data: [][]float64{{1}}}}, {Data: vals, Type: Line}}}},
+		{Type: BoxPlot, Data: Data{Datasets: []Dataset{{Samples: RawSamples{{1}}}, {Data: vals}}}},
+		{Type: Line, Data: Data{Datasets: []Dataset{{Samples: RawSamples{{1}}}}}},
+	} {
+		if _, err := json.Marshal(c); err == nil {
+			t.Fatalf("expected an error for %+v", c)
+		}
+	}
+}
+
+func TestLineStyles(t *testing.T) {
+	c := Chart{Type: Line}
+	c.AddDataset(Dataset{Data: XY{X: []float64{1, 2}, Y: []float64{1, 2}}, BorderDash: []float64{5, 5},
+		BorderCapStyle: CapRound, BorderJoinStyle: JoinBevel})
+	c.AddYAxis(Axis{Type: Linear, GridLines: &GridLineOptions{Color: &types.RGBA{0, 0, 0, 50}, DrawBorder: False,
+		ZeroLineWidth: 2}})
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	for _, want := range []string{`"borderDash":[5,5]`, `"borderCapStyle":"round"`, `"borderJoinStyle":"bevel"`,
+		`"gridLines":{"color":"rgba(0, 0, 0, 0.196)","drawBorder":false,"zeroLineWidth":2}`} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("expected %s in %s", want, b)
+		}
+	}
+	if strings.Contains(string(b), "borderDashOffset") {
+		t.Fatalf("expected no borderDashOffset when it is 0")
+	}
+}
+
+func TestAxisIDs(t *testing.T) {
+	c := Chart{Type: Line}
+	if _, err := c.AddYAxis(Axis{ID: "yaxis1", Type: Linear}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if _, err := c.AddYAxis(Axis{ID: "yaxis1", Type: Log}); err == nil {
+		t.Fatalf("expected an error for a duplicate axis ID")
+	}
+	// the generated ID skips the one that is taken.
+	id, err := c.AddDatasetOnNewAxis(Dataset{Data: XY{X: []float64{1, 2}, Y: []float64{3, 4}}}, Axis{Type: Log})
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if id != "yaxis2" || c.Data.Datasets[0].YAxisID != id {
+		t.Fatalf("expected the dataset on a new yaxis2, got %q and %q", id, c.Data.Datasets[0].YAxisID)
+	}
+	a := c.Axis(id)
+	if a == nil || a.Type != Log || a.Position != Right {
+		t.Fatalf("expected a Log axis on the right, got %+v", a)
+	}
+	a.Display = False
+	if c.Options.Scales.YAxes[1].Display != False {
+		t.Fatalf("expected the axis to be changed through Axis")
+	}
+	if c.Axis("xaxis0") != nil {
+		t.Fatalf("expected no axis for an unknown ID")
+	}
+	if _, err := json.Marshal(c); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	c.AddDataset(Dataset{Data: XY{X: []float64{1}, Y: []float64{1}}, YAxisID: "missing"})
+	if _, err := json.Marshal(c); err == nil || !strings.Contains(err.Error(), `no y-axis "missing"`) {
+		t.Fatalf("expected an error for a missing axis, got %v", err)
+	}
+	// without axes only the IDs of those that Chart.js makes can be used.
+	n := Chart{Type: Line}
+	n.AddDataset(Dataset{Data: XY{X: []float64{1}, Y: []float64{1}}, XAxisID: "x-axis-0", YAxisID: "y-axis-0"})
+	if _, err := json.Marshal(n); err != nil {
+		t.Fatalf("unexpected error for the default axis IDs: %+v", err)
+	}
+	n.Data.Datasets[0].YAxisID = "foo"
+	if _, err := json.Marshal(n); err == nil || !strings.Contains(err.Error(), `no y-axis "foo"`) {
+		t.Fatalf("expected an error for a missing axis on a chart without axes, got %v", err)
+	}
+
+	c.Data.Datasets = c.Data.Datasets[:1]
+	c.Options.Scales.AddY(Axis{ID: "yaxis1"})
+	if _, err := json.Marshal(c); err == nil {
+		t.Fatalf("expected an error for a duplicate axis ID")
+	}
+}
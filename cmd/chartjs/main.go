@@ -0,0 +1,125 @@
+// Command chartjs plots numbers or delimited text from stdin or files to an HTML page.
+//
+//	chartjs hist -y 0 < values.txt
+//	chartjs scatter -x depth -y coverage -group sample -yaxis log -o cov.html data.tsv
+//	chartjs render -open report.yaml
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/brentp/go-chartjs/spec"
+	"github.com/pkg/browser"
+)
+
+const usage = `usage: chartjs <hist|line|scatter|bar|bubble|box|violin> [flags] [files...]
+       chartjs render [-o output] [-open] spec.yaml
+
+Reads delimited text (tab or comma) from the files, or from stdin if there are none,
+and writes a chart to an HTML page. Columns are selected by name or 0-based index.
+render writes the charts described by a YAML or JSON spec file; see package spec.
+
+flags:
+`
+
+var kinds = map[string]bool{"hist": true, "line": true, "scatter": true, "bar": true, "bubble": true,
+	"box": true, "violin": true, "render": true}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "chartjs:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("a chart type is required")
+	}
+	kind := args[0]
+	if !kinds[kind] {
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown chart type %q", kind)
+	}
+
+	var c spec.Chart
+	var d spec.Dataset
+	var out string
+	var open bool
+	page := &spec.Page{Stdin: stdin}
+	fs := flag.NewFlagSet(kind, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&out, "o", "", "path of the HTML output (default chartjs.html or the spec name)")
+	fs.BoolVar(&open, "open", false, "open the output in a browser")
+	if kind != "render" {
+		fs.StringVar(&d.X, "x", "", "column of the x values. The row index is used if it is empty")
+		fs.StringVar(&d.Y, "y", "0", "column of the y values")
+		fs.StringVar(&d.R, "r", "", "column of the radii of a bubble chart")
+		fs.StringVar(&d.Group, "group", "", "column to split the rows into datasets")
+		fs.StringVar(&d.Delimiter, "delim", "", "field delimiter. A tab if the first row has one and a comma otherwise")
+		fs.StringVar(&c.X.Type, "xaxis", "linear", "x axis type: linear, log, log1p, symlog or time (in unix seconds)")
+		fs.StringVar(&c.Y.Type, "yaxis", "linear", "y axis type: linear, log, log1p or symlog")
+		fs.StringVar(&c.Title, "title", "", "title of the chart")
+		fs.StringVar(&c.X.Label, "xlabel", "", "label of the x axis")
+		fs.StringVar(&c.Y.Label, "ylabel", "", "label of the y axis")
+		fs.IntVar(&page.Width, "width", 800, "width of the chart in pixels")
+		fs.IntVar(&page.Height, "height", 600, "height of the chart in pixels")
+		fs.IntVar(&c.Bins, "bins", 0, "number of bins of a histogram. Freedman-Diaconis is used if it is 0")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if kind == "render" {
+		if fs.NArg() != 1 {
+			fs.Usage()
+			return fmt.Errorf("render requires one spec file")
+		}
+		var err error
+		if page, err = spec.ReadFile(fs.Arg(0)); err != nil {
+			return err
+		}
+		page.Stdin = stdin
+	} else {
+		c.Type = kind
+		files := fs.Args()
+		if len(files) == 0 {
+			files = []string{"-"}
+		}
+		for _, f := range files {
+			d.File = f
+			if len(files) > 1 && d.Group == "" {
+				d.Label = filepath.Base(f)
+			}
+			c.Datasets = append(c.Datasets, d)
+		}
+		page.Charts = []spec.Chart{c}
+		page.Output = "chartjs.html"
+	}
+	if out != "" {
+		page.Output = out
+	}
+	page.Stderr = stderr
+
+	var buf bytes.Buffer
+	if err := page.Render(&buf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(page.Output, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	if open {
+		return browser.OpenFile(page.Output)
+	}
+	return nil
+}
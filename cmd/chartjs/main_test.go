@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chartjs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tsv := "x\ty\tsize\tgroup\n1\t2\t3\tA\n2\t4\t5\tB\n3\t8\t7\tA\n4\t9\t2\tB\n"
+
+	for _, c := range []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"hist", "-y", "y", "-bins", "2"}, []string{`"type":"bar"`, `"labels":["2-6","6-9"]`}},
+		{[]string{"scatter", "-x", "x", "-y", "y", "-group", "group", "-yaxis", "log", "-title", "T"},
+			[]string{`"showLine":false`, `"label":"B"`, `"type":"logarithmic"`, `"text":"T"`}},
+		{[]string{"line", "-x", "x", "-y", "y", "-xaxis", "time"}, []string{`"type":"time"`, `{"x":1000.00,"y":2.00}`}},
+		{[]string{"bar", "-y", "y"}, []string{`"data":[2.00,4.00,8.00,9.00]`, `"labels":["0","1","2","3"]`}},
+		{[]string{"bubble", "-x", "x", "-y", "y", "-r", "size"}, []string{`"r":7.00`}},
+		{[]string{"box", "-y", "y", "-group", "group"}, []string{`"type":"boxplot"`, `"labels":["A","B"]`}},
+	} {
+		out := filepath.Join(dir, c.args[0]+".html")
+		args := append(c.args, "-o", out, "-width", "300")
+		if err := run(args, strings.NewReader(tsv), ioutil.Discard); err != nil {
+			t.Fatalf("error running %v: %+v", c.args, err)
+		}
+		b, err := ioutil.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range append(c.want, "width:300px") {
+			if !strings.Contains(string(b), w) {
+				t.Fatalf("%s: expected %q in output", c.args[0], w)
+			}
+		}
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "data.tsv"), []byte(tsv), 0644); err != nil {
+		t.Fatal(err)
+	}
+	yaml := "charts:\n  - type: violin\n    datasets:\n      - {file: data.tsv, y: y, group: group}\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"render", filepath.Join(dir, "spec.yaml")}, nil, ioutil.Discard); err != nil {
+		t.Fatalf("error rendering spec: %+v", err)
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(dir, "spec.html")); err != nil || !strings.Contains(string(b), `"type":"violin"`) {
+		t.Fatalf("expected a violin chart in spec.html: %v", err)
+	}
+
+	// values that can't be placed on a log axis are reported.
+	var stderr bytes.Buffer
+	args := []string{"scatter", "-x", "x", "-y", "y", "-yaxis", "log", "-o", filepath.Join(dir, "log.html")}
+	if err := run(args, strings.NewReader("x\ty\n1\t0\n2\t4\n"), &stderr); err != nil {
+		t.Fatalf("error running %v: %+v", args, err)
+	}
+	if !strings.Contains(stderr.String(), "dataset 0: dropped 1 values") {
+		t.Fatalf("expected a warning about the dropped value, got %q", stderr.String())
+	}
+
+	for _, args := range [][]string{{}, {"pie"}, {"render"}, {"bubble", "-x", "x", "-y", "y"}, {"line", "-yaxis", "time"}} {
+		err := run(append(args, "-o", filepath.Join(dir, "x.html")), strings.NewReader(tsv), ioutil.Discard)
+		if err == nil || strings.Contains(err.Error(), "line 0") {
+			t.Fatalf("expected an error for %v, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package chartjs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+// Palette holds the colors given in turn to the datasets made by ReadDelimited.
+var Palette = []types.RGBA{
+	{102, 194, 165, 220},
+	{250, 141, 98, 220},
+	{141, 159, 202, 220},
+	{230, 138, 195, 220},
+	{166, 216, 84, 220},
+	{255, 217, 47, 220},
+	{229, 196, 148, 220},
+	{179, 179, 179, 220},
+}
+
+// ReadOptions determines how ReadDelimited reads a table. A column is selected by its name in
+// the header or by its 0-based index.
+type ReadOptions struct {
+	// Comma is the field delimiter. If it is 0, it is a tab if the first row has one and a comma otherwise.
+	Comma rune
+	// Header is whether the first row names the columns. If it is unset, the first row is a header
+	// when none of its fields are numbers.
+	Header types.Bool
+
+	// Y is required. If X is empty, the Y values are used as they are as for a Bar chart.
+	X, Y, R string
+	// Group splits the rows into a Dataset for each of its values in the order that they appear.
+	Group string
+}
+
+// table holds the values read for a Dataset.
+type table struct {
+	x, y, r []float64
+}
+
+func (t *table) Xs() []float64 { return t.x }
+func (t *table) Ys() []float64 { return t.y }
+func (t *table) Rs() []float64 { return t.r }
+
+// ReadDelimited reads delimited text such as CSV or TSV from r into datasets. Lines starting
+// with '#' are ignored and empty fields are read as missing values. The datasets are labelled
+// with the Group value, or else with the name of the Y column, and are colored from Palette.
+func ReadDelimited(r io.Reader, o ReadOptions) ([]Dataset, error) {
+	br := bufio.NewReader(r)
+	comma := o.Comma
+	if comma == 0 {
+		comma = ','
+		// an error here just means there is less than a buffer of data.
+		peek, _ := br.Peek(4096)
+		for _, line := range bytes.Split(peek, []byte("\n")) {
+			if len(line) == 0 || line[0] == '#' {
+				continue
+			}
+			if bytes.IndexByte(line, '\t') >= 0 {
+				comma = '\t'
+			}
+			break
+		}
+	}
+	cr := csv.NewReader(br)
+	cr.Comma = comma
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+
+	first, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("chart: no rows to read")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("chart: %s", err)
+	}
+	header := o.Header != nil && *o.Header
+	if o.Header == nil {
+		header = true
+		for _, f := range first {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(f), 64); err == nil {
+				header = false
+				break
+			}
+		}
+	}
+	var names []string
+	if header {
+		names = append(names, first...)
+	}
+
+	column := func(c string) (int, error) {
+		if c == "" {
+			return -1, nil
+		}
+		for i, n := range names {
+			if strings.TrimSpace(n) == c {
+				return i, nil
+			}
+		}
+		i, err := strconv.Atoi(c)
+		if err != nil || i < 0 {
+			return -1, fmt.Errorf("chart: no column %q", c)
+		}
+		return i, nil
+	}
+	if o.Y == "" {
+		return nil, fmt.Errorf("chart: a Y column is required")
+	}
+	var cols [4]int
+	for k, c := range []string{o.X, o.Y, o.R, o.Group} {
+		if cols[k], err = column(c); err != nil {
+			return nil, err
+		}
+	}
+	xi, yi, ri, gi := cols[0], cols[1], cols[2], cols[3]
+
+	label := o.Y
+	if yi < len(names) {
+		label = strings.TrimSpace(names[yi])
+	}
+	var datasets []Dataset
+	tables := make(map[string]*table)
+	add := func(record []string) error {
+		line, _ := cr.FieldPos(0)
+		value := func(i int) (float64, error) {
+			if i >= len(record) {
+				return 0, fmt.Errorf("chart: line %d: no column %d", line, i+1)
+			}
+			f := strings.TrimSpace(record[i])
+			if f == "" {
+				return math.NaN(), nil
+			}
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return 0, fmt.Errorf("chart: line %d, column %d: %q is not a number", line, i+1, f)
+			}
+			return v, nil
+		}
+		var group string
+		if gi >= 0 {
+			if gi >= len(record) {
+				return fmt.Errorf("chart: line %d: no column %d", line, gi+1)
+			}
+			group = strings.TrimSpace(record[gi])
+		}
+		t, ok := tables[group]
+		if !ok {
+			t = &table{}
+			tables[group] = t
+			c := Palette[len(datasets)%len(Palette)]
+			l := label
+			if gi >= 0 {
+				l = group
+			}
+			datasets = append(datasets, Dataset{Data: t, Label: l, BackgroundColor: &c, BorderColor: &c})
+		}
+		y, err := value(yi)
+		if err != nil {
+			return err
+		}
+		t.y = append(t.y, y)
+		if xi >= 0 {
+			x, err := value(xi)
+			if err != nil {
+				return err
+			}
+			t.x = append(t.x, x)
+		}
+		if ri >= 0 {
+			r, err := value(ri)
+			if err != nil {
+				return err
+			}
+			t.r = append(t.r, r)
+		}
+		return nil
+	}
+
+	if !header {
+		if err := add(first); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("chart: %s", err)
+		}
+		if err := add(record); err != nil {
+			return nil, err
+		}
+	}
+	if len(datasets) == 0 {
+		// there was only a header.
+		return nil, fmt.Errorf("chart: no rows to read")
+	}
+	return datasets, nil
+}
@@ -0,0 +1,55 @@
+package chartjs
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestReadDelimited(t *testing.T) {
+	tsv := "# a comment\nx\ty\tsize\tgroup\n1\t2\t3\tA\n2\t4\t5\tB\n3\t\t7\tA\n"
+	ds, err := ReadDelimited(strings.NewReader(tsv), ReadOptions{X: "x", Y: "1", R: "size", Group: "group"})
+	if err != nil {
+		t.Fatalf("error reading tsv: %+v", err)
+	}
+	if len(ds) != 2 || ds[0].Label != "A" || ds[1].Label != "B" {
+		t.Fatalf("expected datasets A and B, got %+v", ds)
+	}
+	if *ds[0].BackgroundColor != Palette[0] || *ds[1].BorderColor != Palette[1] {
+		t.Fatalf("expected colors from the palette")
+	}
+	a := ds[0].Data
+	if xs, ys, rs := a.Xs(), a.Ys(), a.Rs(); len(xs) != 2 || xs[1] != 3 || !math.IsNaN(ys[1]) || rs[1] != 7 {
+		t.Fatalf("unexpected values: %v %v %v", xs, ys, rs)
+	}
+	if _, err := json.Marshal(ds[0]); err != nil {
+		t.Fatalf("error marshaling dataset: %+v", err)
+	}
+
+	ds, err = ReadDelimited(strings.NewReader("1,10\n2,20\n"), ReadOptions{Y: "1"})
+	if err != nil {
+		t.Fatalf("error reading csv: %+v", err)
+	}
+	if len(ds) != 1 || ds[0].Data.Xs() != nil || len(ds[0].Data.Ys()) != 2 || ds[0].Label != "1" {
+		t.Fatalf("expected a dataset of the second column without a header, got %+v", ds)
+	}
+
+	for _, c := range []struct {
+		in   string
+		o    ReadOptions
+		want string
+	}{
+		{"x,y\n1,2\n3,z\n", ReadOptions{X: "x", Y: "y"}, "line 3, column 2"},
+		{"x,y\n1,2\n3\n", ReadOptions{X: "x", Y: "y"}, "line 3: no column 2"},
+		{"x,y\n1,\"2\n", ReadOptions{X: "x", Y: "y"}, "line 2"},
+		{"x,y\n1,2\n", ReadOptions{X: "x", Y: "w"}, `no column "w"`},
+		{"x,y\n1,2\n", ReadOptions{X: "x"}, "Y column is required"},
+		{"x,y\n", ReadOptions{X: "x", Y: "y"}, "no rows to read"},
+	} {
+		_, err := ReadDelimited(strings.NewReader(c.in), c.o)
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Fatalf("expected error containing %q for %q, got %v", c.want, c.in, err)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package chartjs
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// ErrorValues is an optional interface that Values may implement to show uncertainty.
+// YLow and YHigh must be the same length as the plotted values.
+type ErrorValues interface {
+	Values
+	YLow() []float64
+	YHigh() []float64
+}
+
+// XErrorValues may be implemented in addition to ErrorValues to show uncertainty in X.
+// It is only used with ErrorBars.
+type XErrorValues interface {
+	XLow() []float64
+	XHigh() []float64
+}
+
+type errorMode int
+
+const (
+	// ErrorBars draws whiskers from low to high on each point or bar (this is the default).
+	ErrorBars errorMode = iota
+	// ErrorBand draws a band between low and high filled with the BackgroundColor of the Dataset.
+	ErrorBand
+)
+
+// formatFloat formats v with the given format or returns null for NaN.
+func formatFloat(format string, v float64) string {
+	if math.IsNaN(v) {
+		return "null"
+	}
+	return fmt.Sprintf(format, v)
+}
+
+func marshalErrorBarsJSON(v ErrorValues, xformat, yformat string) ([]byte, error) {
+	lo, hi := v.YLow(), v.YHigh()
+	if len(lo) != len(hi) {
+		return nil, fmt.Errorf("chart: bad format of ErrorValues. YLow and YHigh must be of the same length")
+	}
+	n := len(v.Ys())
+	if n == 0 {
+		// a single set of values.
+		n = len(v.Xs())
+	}
+	if len(lo) != n {
+		return nil, fmt.Errorf("chart: bad format of ErrorValues. YLow and YHigh must match the values in length")
+	}
+	var xlo, xhi []float64
+	if xv, ok := v.(XErrorValues); ok {
+		xlo, xhi = xv.XLow(), xv.XHigh()
+		if len(xlo) != len(lo) || len(xhi) != len(lo) {
+			return nil, fmt.Errorf("chart: bad format of ErrorValues. XLow and XHigh must match YLow in length")
+		}
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, 16*len(lo)))
+	buf.WriteRune('[')
+	for i := range lo {
+		if i > 0 {
+			buf.WriteRune(',')
+		}
+		fmt.Fprintf(buf, `{"yMin":%s,"yMax":%s`, formatFloat(yformat, lo[i]), formatFloat(yformat, hi[i]))
+		if xlo != nil {
+			fmt.Fprintf(buf, `,"xMin":%s,"xMax":%s`, formatFloat(xformat, xlo[i]), formatFloat(xformat, xhi[i]))
+		}
+		buf.WriteRune('}')
+	}
+	buf.WriteRune(']')
+	return buf.Bytes(), nil
+}
+
+// band holds one edge of an ErrorBand.
+type band struct {
+	xs []float64
+	ys []float64
+}
+
+func (b band) Xs() []float64 { return b.xs }
+func (b band) Ys() []float64 { return b.ys }
+func (b band) Rs() []float64 { return nil }
+
+// bands returns the low and high datasets that draw the ErrorBand of d.
+// The high dataset is filled to the low dataset which precedes it.
+func bands(d Dataset, ev ErrorValues) (lo, hi Dataset, err error) {
+	ylo, yhi := ev.YLow(), ev.YHigh()
+	if len(ylo) != len(yhi) {
+		return lo, hi, fmt.Errorf("chart: bad format of ErrorValues. YLow and YHigh must be of the same length")
+	}
+	xs := ev.Xs()
+	if len(ev.Ys()) == 0 {
+		// only a single set of values so they are sent without X.
+		xs = nil
+	}
+	lo = Dataset{
+		Data:            band{xs: xs, ys: ylo},
+		Type:            Line,
+		Label:           d.Label + " (low)",
+		Fill:            NoFill,
+		BackgroundColor: d.BackgroundColor,
+		XAxisID:         d.XAxisID,
+		YAxisID:         d.YAxisID,
+		XFloatFormat:    d.XFloatFormat,
+		YFloatFormat:    d.YFloatFormat,
+		band:            true,
+	}
+	hi = lo
+	hi.Data = band{xs: xs, ys: yhi}
+	hi.Label = d.Label + " (high)"
+	hi.Fill = FillRelative(-1)
+	return lo, hi, nil
+}
+
+// errorBandPlugin hides the datasets that Dataset.MarshalJSON sends with "errorBand" from the
+// legend so that the band can't be half hidden.
+const errorBandPlugin = `
+Chart.plugins.register({
+	beforeInit: function(chart) {
+		var labels = chart.options.legend && chart.options.legend.labels;
+		if (!labels) { return; }
+		var filter = labels.filter;
+		labels.filter = function(item, data) {
+			if (data.datasets[item.datasetIndex].errorBand) { return false; }
+			return filter ? filter.call(this, item, data) : true;
+		};
+	}
+});
+`
+
+// errorBarsPlugin draws the "errorBars" that Dataset.MarshalJSON sends for ErrorValues.
+const errorBarsPlugin = `
+Chart.plugins.register({
+	afterDatasetsDraw: function(chart) {
+		var ctx = chart.ctx;
+		chart.data.datasets.forEach(function(ds, i) {
+			if (!ds.errorBars || !chart.isDatasetVisible(i)) { return; }
+			var meta = chart.getDatasetMeta(i);
+			var xscale = chart.scales[meta.xAxisID], yscale = chart.scales[meta.yAxisID];
+			ctx.save();
+			ctx.strokeStyle = ds.borderColor || 'rgba(0, 0, 0, 0.8)';
+			ctx.lineWidth = 1;
+			meta.data.forEach(function(el, j) {
+				var e = ds.errorBars[j], m = el._model, w = 4;
+				if (!e) { return; }
+				ctx.beginPath();
+				if (e.yMin !== null && e.yMax !== null) {
+					var lo = yscale.getPixelForValue(e.yMin), hi = yscale.getPixelForValue(e.yMax);
+					ctx.moveTo(m.x, lo); ctx.lineTo(m.x, hi);
+					ctx.moveTo(m.x - w, lo); ctx.lineTo(m.x + w, lo);
+					ctx.moveTo(m.x - w, hi); ctx.lineTo(m.x + w, hi);
+				}
+				if (e.xMin !== undefined && e.xMin !== null && e.xMax !== null) {
+					var left = xscale.getPixelForValue(e.xMin), right = xscale.getPixelForValue(e.xMax);
+					ctx.moveTo(left, m.y); ctx.lineTo(right, m.y);
+					ctx.moveTo(left, m.y - w); ctx.lineTo(left, m.y + w);
+					ctx.moveTo(right, m.y - w); ctx.lineTo(right, m.y + w);
+				}
+				ctx.stroke();
+			});
+			ctx.restore();
+		});
+	}
+});
+`
@@ -0,0 +1,108 @@
+package chartjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+type xyerr struct {
+	XY
+	lo []float64
+	hi []float64
+}
+
+func (v xyerr) YLow() []float64 {
+	return v.lo
+}
+func (v xyerr) YHigh() []float64 {
+	return v.hi
+}
+
+func sinErr() xyerr {
+	var v xyerr
+	for i := float64(0); i < 6; i += 0.5 {
+		v.X = append(v.X, i)
+		v.Y = append(v.Y, math.Sin(i))
+		v.lo = append(v.lo, math.Sin(i)-0.2)
+		v.hi = append(v.hi, math.Sin(i)+0.2)
+	}
+	return v
+}
+
+func TestErrorBars(t *testing.T) {
+	d := Dataset{Data: sinErr(), Label: "sin(x)"}
+	chart := Chart{Type: Line}
+	chart.AddDataset(d)
+
+	b, err := json.Marshal(chart)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	if !strings.Contains(string(b), `"errorBars":[{"yMin":-0.20,"yMax":0.20}`) {
+		t.Fatalf("expected errorBars in %s", b)
+	}
+	if len(chart.plugins()) != 1 {
+		t.Fatalf("expected error bar plugin to be required")
+	}
+
+	var buf bytes.Buffer
+	if err := chart.SaveHTML(&buf, nil); err != nil {
+		t.Fatalf("error saving chart: %+v", err)
+	}
+	if !strings.Contains(buf.String(), "afterDatasetsDraw") {
+		t.Fatalf("expected error bar plugin in html")
+	}
+}
+
+func TestErrorBand(t *testing.T) {
+	d := Dataset{Data: sinErr(), Label: "sin(x)", ErrorMode: ErrorBand, BackgroundColor: &types.RGBA{0, 0, 255, 60}}
+	chart := Chart{Type: Line}
+	chart.AddDataset(d)
+
+	b, err := json.Marshal(chart)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	var v struct {
+		Data struct {
+			Datasets []map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("error unmarshaling chart: %+v", err)
+	}
+	if len(v.Data.Datasets) != 3 {
+		t.Fatalf("expected 3 datasets, got %d", len(v.Data.Datasets))
+	}
+	if v.Data.Datasets[1]["fill"] != "-1" {
+		t.Fatalf("expected high band to be filled to low band, got %v", v.Data.Datasets[1]["fill"])
+	}
+	if _, ok := v.Data.Datasets[2]["errorBars"]; ok {
+		t.Fatalf("expected no errorBars with ErrorBand")
+	}
+	// the bands are left out of the legend.
+	if v.Data.Datasets[0]["errorBand"] != true || v.Data.Datasets[1]["errorBand"] != true || v.Data.Datasets[2]["errorBand"] != nil {
+		t.Fatalf("expected only the bands to be marked, got %s", b)
+	}
+	if p := chart.plugins(); len(p) != 1 || p[0] != "errorBand" {
+		t.Fatalf("expected only the errorBand plugin with ErrorBand, got %v", p)
+	}
+}
+
+func TestErrorBarsLength(t *testing.T) {
+	v := sinErr()
+	v.hi = v.hi[:2]
+	if _, err := json.Marshal(Dataset{Data: v}); err == nil {
+		t.Fatalf("expected error with mismatched YLow and YHigh")
+	}
+	v = sinErr()
+	v.lo, v.hi = v.lo[:2], v.hi[:2]
+	if _, err := json.Marshal(Dataset{Data: v}); err == nil {
+		t.Fatalf("expected error with YLow and YHigh shorter than the values")
+	}
+}
@@ -0,0 +1,110 @@
+package chartjs
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+// FillMode determines how the area under a line Dataset is filled. The zero FillMode is unset
+// and uses the default of Chart.js, which fills Line datasets to the origin.
+type FillMode string
+
+const (
+	// NoFill doesn't fill the area.
+	NoFill FillMode = "false"
+	// FillTrue is the same as FillOrigin. It is the fill of a Fill of True.
+	FillTrue FillMode = "true"
+	// FillOrigin fills to y = 0.
+	FillOrigin FillMode = "origin"
+	// FillStart fills to the bottom of the chart.
+	FillStart FillMode = "start"
+	// FillEnd fills to the top of the chart.
+	FillEnd FillMode = "end"
+)
+
+// FillBool converts a types.Bool such as True or False to a FillMode.
+func FillBool(b types.Bool) FillMode {
+	switch {
+	case b == nil:
+		return ""
+	case *b:
+		return FillTrue
+	}
+	return NoFill
+}
+
+// FillDataset fills to the dataset at index i of Data.Datasets.
+func FillDataset(i int) FillMode {
+	return FillMode(strconv.Itoa(i))
+}
+
+// FillRelative fills to the dataset n places from this one; -1 is the dataset before it.
+func FillRelative(n int) FillMode {
+	return FillMode(fmt.Sprintf("%+d", n))
+}
+
+// Dataset returns the index of the dataset that the dataset at index i is filled to, if any.
+func (f FillMode) Dataset(i int) (int, bool) {
+	if len(f) == 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(string(f))
+	if err != nil {
+		return 0, false
+	}
+	if f[0] == '+' || f[0] == '-' {
+		return i + n, true
+	}
+	return n, true
+}
+
+// valid returns an error if the mode isn't one of the forms of Chart.js.
+func (f FillMode) valid() error {
+	switch f {
+	case "", NoFill, FillTrue, FillOrigin, FillStart, FillEnd:
+		return nil
+	}
+	if _, err := strconv.Atoi(string(f)); err != nil {
+		return fmt.Errorf("chart: unknown fill mode %q", string(f))
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (f FillMode) MarshalJSON() ([]byte, error) {
+	if err := f.valid(); err != nil {
+		return nil, err
+	}
+	if f == NoFill || f == FillTrue {
+		return []byte(f), nil
+	}
+	if _, err := strconv.Atoi(string(f)); err == nil && f[0] != '+' && f[0] != '-' {
+		// an absolute index is sent as a number.
+		return []byte(f), nil
+	}
+	return []byte(strconv.Quote(string(f))), nil
+}
+
+// fillTargets checks that the datasets that are filled to exist and moves them to the index of
+// each dataset in pos, which holds where each dataset is sent after any bands are added.
+func fillTargets(datasets []Dataset, pos []int) ([]Dataset, error) {
+	out := make([]Dataset, len(datasets))
+	for i, d := range datasets {
+		out[i] = d
+		t, ok := d.Fill.Dataset(i)
+		if !ok {
+			continue
+		}
+		if t < 0 || t >= len(datasets) || t == i {
+			return nil, fmt.Errorf("chart: dataset %d fills to dataset %d, which doesn't exist", i, t)
+		}
+		if f := d.Fill; f[0] == '+' || f[0] == '-' {
+			out[i].Fill = FillRelative(pos[t] - pos[i])
+		} else {
+			out[i].Fill = FillDataset(pos[t])
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package chartjs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+func TestFillMarshal(t *testing.T) {
+	for _, c := range []struct {
+		f    FillMode
+		want string
+	}{
+		{NoFill, `false`},
+		{FillTrue, `true`},
+		{FillBool(False), `false`},
+		{FillBool(True), `true`},
+		{FillOrigin, `"origin"`},
+		{FillStart, `"start"`},
+		{FillEnd, `"end"`},
+		{FillDataset(2), `2`},
+		{FillRelative(-1), `"-1"`},
+		{FillRelative(1), `"+1"`},
+	} {
+		b, err := json.Marshal(c.f)
+		if err != nil {
+			t.Fatalf("error marshaling %q: %+v", c.f, err)
+		}
+		if string(b) != c.want {
+			t.Fatalf("expected %s for %q, got %s", c.want, c.f, b)
+		}
+	}
+	if FillBool(nil) != "" {
+		t.Fatalf("expected an unset Bool to leave the fill unset")
+	}
+	if _, err := json.Marshal(FillMode("under")); err == nil {
+		t.Fatalf("expected error with unknown fill mode")
+	}
+}
+
+func TestFillDataset(t *testing.T) {
+	if i, ok := FillRelative(-1).Dataset(3); !ok || i != 2 {
+		t.Fatalf("expected -1 from 3 to be 2, got %d %v", i, ok)
+	}
+	if i, ok := FillDataset(0).Dataset(3); !ok || i != 0 {
+		t.Fatalf("expected dataset 0, got %d %v", i, ok)
+	}
+	if _, ok := FillOrigin.Dataset(3); ok {
+		t.Fatalf("expected no dataset for origin")
+	}
+}
+
+func TestFillTargets(t *testing.T) {
+	c := Chart{Type: Line}
+	c.AddDataset(Dataset{Data: XY{X: []float64{1, 2}, Y: []float64{1, 2}}, Fill: FillRelative(1)})
+	c.AddDataset(Dataset{Data: sinErr(), ErrorMode: ErrorBand, BackgroundColor: &types.RGBA{0, 0, 255, 60}})
+	c.AddDataset(Dataset{Data: XY{X: []float64{1, 2}, Y: []float64{3, 4}}, Fill: FillDataset(0)})
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	var v struct {
+		Data struct {
+			Datasets []map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("error unmarshaling chart: %+v", err)
+	}
+	ds := v.Data.Datasets
+	if len(ds) != 5 {
+		t.Fatalf("expected 5 datasets, got %d", len(ds))
+	}
+	// the band of the second dataset comes before it so the first now fills 3 places on.
+	if ds[0]["fill"] != "+3" {
+		t.Fatalf("expected the first dataset to fill to +3, got %v", ds[0]["fill"])
+	}
+	if ds[4]["fill"] != float64(0) {
+		t.Fatalf("expected the last dataset to fill to 0, got %v", ds[4]["fill"])
+	}
+
+	c.Data.Datasets[2].Fill = FillRelative(1)
+	if _, err := json.Marshal(c); err == nil {
+		t.Fatalf("expected error filling to a missing dataset")
+	}
+}
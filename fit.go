@@ -0,0 +1,126 @@
+package chartjs
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/brentp/go-chartjs/stats"
+)
+
+// this file implements overlays of trend lines on scatter datasets.
+
+// overlay returns a line Dataset of c drawn on the same axes as d.
+func overlay(d Dataset, c *stats.Curve, label string) Dataset {
+	if d.Label != "" {
+		label = d.Label + ": " + label
+	}
+	return Dataset{
+		Data:         c,
+		Type:         Line,
+		Label:        label,
+		Fill:         NoFill,
+		ShowLine:     True,
+		BorderColor:  d.BorderColor,
+		BorderWidth:  2,
+		XAxisID:      d.XAxisID,
+		YAxisID:      d.YAxisID,
+		XFloatFormat: d.XFloatFormat,
+		YFloatFormat: d.YFloatFormat,
+	}
+}
+
+// fitValues returns the X and Y values of d which must both be present.
+func fitValues(d Dataset) ([]float64, []float64, error) {
+	if d.Data == nil {
+		return nil, nil, fmt.Errorf("chart: dataset has no Data to fit")
+	}
+	xs, ys := d.Data.Xs(), d.Data.Ys()
+	if len(xs) == 0 || len(ys) == 0 {
+		return nil, nil, fmt.Errorf("chart: dataset must have X and Y values to fit")
+	}
+	return xs, ys, nil
+}
+
+// equation formats the polynomial with coefficients in increasing order of degree.
+func equation(coef []float64) string {
+	var terms []string
+	for i := len(coef) - 1; i >= 0; i-- {
+		c := coef[i]
+		var t string
+		switch i {
+		case 0:
+			t = fmt.Sprintf("%.3g", math.Abs(c))
+		case 1:
+			t = fmt.Sprintf("%.3gx", math.Abs(c))
+		default:
+			t = fmt.Sprintf("%.3gx^%d", math.Abs(c), i)
+		}
+		switch {
+		case len(terms) == 0 && c < 0:
+			t = "-" + t
+		case len(terms) > 0 && c < 0:
+			t = "- " + t
+		case len(terms) > 0:
+			t = "+ " + t
+		}
+		terms = append(terms, t)
+	}
+	return "y = " + strings.Join(terms, " ")
+}
+
+// LinearFit returns a Dataset with the ordinary least squares line through d.
+// Its Label holds the equation and R².
+func LinearFit(d Dataset) (Dataset, error) {
+	return PolyFit(d, 1)
+}
+
+// PolyFit returns a Dataset with the least squares polynomial of the given degree through d.
+// Its Label holds the equation and R².
+func PolyFit(d Dataset, degree int) (Dataset, error) {
+	xs, ys, err := fitValues(d)
+	if err != nil {
+		return Dataset{}, err
+	}
+	coef, r2, err := stats.PolyFit(xs, ys, degree)
+	if err != nil {
+		return Dataset{}, err
+	}
+	sorted := stats.Sorted(xs)
+	// a line only needs its ends but curves are evaluated across the range.
+	c := &stats.Curve{X: []float64{sorted[0], sorted[len(sorted)-1]}}
+	if degree > 1 {
+		c.X = stats.Linspace(c.X[0], c.X[1], 100)
+	}
+	for _, x := range c.X {
+		c.Y = append(c.Y, stats.Poly(coef, x))
+	}
+	return overlay(d, c, fmt.Sprintf("%s (R² = %.3f)", equation(coef), r2)), nil
+}
+
+// Lowess returns a Dataset with the locally weighted regression through d using frac of the
+// points for each local fit. 0.66 is a good default for frac.
+func Lowess(d Dataset, frac float64) (Dataset, error) {
+	xs, ys, err := fitValues(d)
+	if err != nil {
+		return Dataset{}, err
+	}
+	c, err := stats.Lowess(xs, ys, frac, 3)
+	if err != nil {
+		return Dataset{}, err
+	}
+	return overlay(d, c, fmt.Sprintf("LOWESS (f = %.2g)", frac)), nil
+}
+
+// MovingAverage returns a Dataset with the centered moving average of d over window points.
+func MovingAverage(d Dataset, window int) (Dataset, error) {
+	xs, ys, err := fitValues(d)
+	if err != nil {
+		return Dataset{}, err
+	}
+	c, err := stats.MovingAverage(xs, ys, window)
+	if err != nil {
+		return Dataset{}, err
+	}
+	return overlay(d, c, fmt.Sprintf("moving average (n = %d)", window)), nil
+}
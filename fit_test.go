@@ -0,0 +1,43 @@
+package chartjs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinearFit(t *testing.T) {
+	var xys XY
+	for i := 0; i < 10; i++ {
+		xys.X = append(xys.X, float64(i))
+		xys.Y = append(xys.Y, 3-2*float64(i))
+	}
+	d := Dataset{Data: xys, Label: "data", YAxisID: "yaxis1"}
+	f, err := LinearFit(d)
+	if err != nil {
+		t.Fatalf("error fitting: %+v", err)
+	}
+	if f.Label != "data: y = -2x + 3 (R² = 1.000)" {
+		t.Fatalf("unexpected label: %s", f.Label)
+	}
+	if f.YAxisID != "yaxis1" || f.Type != Line {
+		t.Fatalf("expected overlay on the same axis")
+	}
+
+	for _, fit := range []func(Dataset) (Dataset, error){
+		func(d Dataset) (Dataset, error) { return PolyFit(d, 3) },
+		func(d Dataset) (Dataset, error) { return Lowess(d, 0.66) },
+		func(d Dataset) (Dataset, error) { return MovingAverage(d, 3) },
+	} {
+		f, err := fit(d)
+		if err != nil {
+			t.Fatalf("error fitting: %+v", err)
+		}
+		if !strings.HasPrefix(f.Label, "data: ") || len(f.Data.Xs()) != len(f.Data.Ys()) {
+			t.Fatalf("unexpected overlay: %+v", f)
+		}
+	}
+
+	if _, err := LinearFit(Dataset{Data: Ys{1, 2}}); err == nil {
+		t.Fatalf("expected error fitting a dataset without Y")
+	}
+}
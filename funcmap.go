@@ -0,0 +1,61 @@
+package chartjs
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+// FuncMap returns functions to embed charts in pages rendered with html/template:
+//
+//	{{ chartjsScripts .Coverage }} writes the <script> tags for Chart.js and any plugins
+//	    used by the given charts. Use it once per page, before the charts.
+//	{{ chartjs .Coverage }} writes a canvas and the javascript that draws the chart on it.
+//	    The width and height in pixels may follow the chart; both default to 400.
+//
+// The charts are marshalled as with SaveCharts.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"chartjs":        embedChart,
+		"chartjsScripts": embedScripts,
+	}
+}
+
+const scriptsTmpl = `<script src="{{ index . "ChartJS" }}"></script>
+{{ range index . "scripts" }}<script src="{{ . }}"></script>
+{{ end }}`
+
+func embedChart(c Chart, size ...int) (template.HTML, error) {
+	if len(size) > 2 {
+		return "", fmt.Errorf("chart: expected width and height, got %d sizes", len(size))
+	}
+	prefix, err := uniquePrefix()
+	if err != nil {
+		return "", err
+	}
+	tmap := map[string]interface{}{"prefix": prefix}
+	if len(size) > 0 {
+		tmap["width"] = size[0]
+	}
+	if len(size) > 1 {
+		tmap["height"] = size[1]
+	}
+	var buf bytes.Buffer
+	if err := save(&buf, tmap, `<div>`+fragmentBody+`</div>`, []Chart{c}); err != nil {
+		return "", err
+	}
+	// the output is already escaped by html/template.
+	return template.HTML(buf.String()), nil
+}
+
+func embedScripts(charts ...Chart) (template.HTML, error) {
+	t, err := template.New("scripts").Parse(scriptsTmpl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, map[string]interface{}{"ChartJS": ChartJS, "scripts": scripts(charts)}); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
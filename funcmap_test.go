@@ -0,0 +1,42 @@
+package chartjs
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestFuncMap(t *testing.T) {
+	line := Chart{Type: Line}
+	line.AddDataset(Dataset{Data: XY{X: []float64{1, 2}, Y: []float64{3, 4}}, Label: "a<b"})
+	box := Chart{Type: BoxPlot}
+	box.AddDataset(Dataset{Samples: RawSamples{{1, 2, 3, 4}}})
+
+	page := template.Must(template.New("page").Funcs(FuncMap()).Parse(
+		`<html><head>{{ chartjsScripts .Line .Box }}</head><body>{{ chartjs .Line 600 300 }}{{ chartjs .Box }}</body></html>`))
+	var buf bytes.Buffer
+	if err := page.Execute(&buf, map[string]Chart{"Line": line, "Box": box}); err != nil {
+		t.Fatalf("error executing template: %+v", err)
+	}
+	s := buf.String()
+	for _, want := range []string{`<script src="` + ChartJS + `">`, `<script src="` + BoxPlotJS + `">`,
+		"width:600px", "height:300px", "new Chart(", `"label":"a\u003cb"`} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("expected %q in %s", want, s)
+		}
+	}
+	if n := strings.Count(s, "<canvas"); n != 2 {
+		t.Fatalf("expected 2 canvases, got %d", n)
+	}
+	if strings.Count(s, ChartJS) != 1 {
+		t.Fatalf("expected Chart.js to be included once")
+	}
+	if strings.Contains(s, "&lt;canvas") {
+		t.Fatalf("expected the canvas not to be escaped")
+	}
+
+	if _, err := embedChart(line, 1, 2, 3); err == nil {
+		t.Fatalf("expected an error for too many sizes")
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/brentp/go-chartjs
+
+go 1.23.0
+
+require (
+	github.com/pkg/browser v0.0.0-20240102092130-5ac0b6a4141c
+	golang.org/x/image v0.25.0
+	gonum.org/v1/gonum v0.16.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.9.0 // indirect
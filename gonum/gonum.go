@@ -0,0 +1,152 @@
+// Package gonum adapts gonum matrices, plotter data and stat results to go-chartjs.
+// The values are read from the gonum types when the chart is marshalled rather than
+// copied when the adapter is made.
+package gonum
+
+import (
+	"fmt"
+
+	chartjs "github.com/brentp/go-chartjs"
+	"github.com/brentp/go-chartjs/stats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// XYer matches gonum.org/v1/plot/plotter.XYer so that its implementations may be used directly.
+type XYer interface {
+	Len() int
+	XY(int) (x, y float64)
+}
+
+// XYZer matches gonum.org/v1/plot/plotter.XYZer.
+type XYZer interface {
+	Len() int
+	XYZ(int) (x, y, z float64)
+	XY(int) (x, y float64)
+}
+
+type columns struct {
+	m       mat.Matrix
+	x, y, r int
+}
+
+func (c columns) col(j int) []float64 {
+	if j < 0 {
+		return nil
+	}
+	return mat.Col(nil, j, c.m)
+}
+
+func (c columns) Xs() []float64 { return c.col(c.x) }
+func (c columns) Ys() []float64 { return c.col(c.y) }
+func (c columns) Rs() []float64 { return c.col(c.r) }
+
+// Column returns the values of column j of m, as for a Bar chart.
+func Column(m mat.Matrix, j int) chartjs.Values {
+	return columns{m: m, x: j, y: -1, r: -1}
+}
+
+// Columns returns the points with x from column x of m and y from column y.
+func Columns(m mat.Matrix, x, y int) chartjs.Values {
+	return columns{m: m, x: x, y: y, r: -1}
+}
+
+// BubbleColumns returns the points of Columns with radii from column r of m.
+func BubbleColumns(m mat.Matrix, x, y, r int) chartjs.Values {
+	return columns{m: m, x: x, y: y, r: r}
+}
+
+type vector struct {
+	v mat.Vector
+}
+
+func (v vector) Xs() []float64 {
+	n := v.v.Len()
+	if d, ok := v.v.(mat.RawVectorer); ok {
+		if raw := d.RawVector(); raw.Inc == 1 {
+			return raw.Data[:n]
+		}
+	}
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = v.v.AtVec(i)
+	}
+	return xs
+}
+func (v vector) Ys() []float64 { return nil }
+func (v vector) Rs() []float64 { return nil }
+
+// Vector returns the values of v, as for a Bar chart.
+func Vector(v mat.Vector) chartjs.Values {
+	return vector{v}
+}
+
+type xyer struct {
+	xy XYer
+}
+
+func (p xyer) Xs() []float64 {
+	xs := make([]float64, p.xy.Len())
+	for i := range xs {
+		xs[i], _ = p.xy.XY(i)
+	}
+	return xs
+}
+
+func (p xyer) Ys() []float64 {
+	ys := make([]float64, p.xy.Len())
+	for i := range ys {
+		_, ys[i] = p.xy.XY(i)
+	}
+	return ys
+}
+
+func (p xyer) Rs() []float64 {
+	xyz, ok := p.xy.(XYZer)
+	if !ok {
+		return nil
+	}
+	rs := make([]float64, xyz.Len())
+	for i := range rs {
+		_, _, rs[i] = xyz.XYZ(i)
+	}
+	return rs
+}
+
+// XYs returns the points of a plotter.XYer such as plotter.XYs.
+func XYs(xy XYer) chartjs.Values {
+	return xyer{xy: xy}
+}
+
+// XYZs returns the points of a plotter.XYZer such as plotter.XYZs with Z used as the radius
+// of a Bubble chart.
+func XYZs(xyz XYZer) chartjs.Values {
+	return xyer{xy: xyz}
+}
+
+// Heatmap satisfies chartjs.Matrix for a mat.Matrix. Rows and Cols label the cells and the
+// indexes are used if they are nil.
+type Heatmap struct {
+	mat.Matrix
+	Rows, Cols []string
+}
+
+// RowLabels satisfies chartjs.Matrix.
+func (h Heatmap) RowLabels() []string { return h.Rows }
+
+// ColLabels satisfies chartjs.Matrix.
+func (h Heatmap) ColLabels() []string { return h.Cols }
+
+// Histogram returns a Bar dataset and labels for the counts from stat.Histogram, which has
+// one fewer count than dividers.
+func Histogram(counts, dividers []float64) (chartjs.Dataset, []string, error) {
+	if len(dividers) != len(counts)+1 {
+		return chartjs.Dataset{}, nil, fmt.Errorf("gonum: expected %d dividers for %d counts, got %d", len(counts)+1, len(counts), len(dividers))
+	}
+	h := &stats.Hist{Edges: dividers, Counts: counts}
+	return chartjs.Dataset{Data: h, Type: chartjs.Bar}, h.Labels(), nil
+}
+
+// ROC returns the curve of the true positive rate against the false positive rate from stat.ROC.
+func ROC(tpr, fpr []float64) chartjs.Values {
+	return &stats.Curve{X: fpr, Y: tpr}
+}
@@ -0,0 +1,93 @@
+package gonum
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	chartjs "github.com/brentp/go-chartjs"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+type xyzs []struct{ X, Y, Z float64 }
+
+func (p xyzs) Len() int                    { return len(p) }
+func (p xyzs) XY(i int) (x, y float64)     { return p[i].X, p[i].Y }
+func (p xyzs) XYZ(i int) (x, y, z float64) { return p[i].X, p[i].Y, p[i].Z }
+
+func TestColumns(t *testing.T) {
+	m := mat.NewDense(3, 3, []float64{
+		1, 10, 5,
+		2, 20, 6,
+		3, 30, 7,
+	})
+	v := BubbleColumns(m, 0, 1, 2)
+	if xs, ys, rs := v.Xs(), v.Ys(), v.Rs(); xs[2] != 3 || ys[1] != 20 || rs[0] != 5 {
+		t.Fatalf("unexpected values: %v %v %v", xs, ys, rs)
+	}
+	if v := Columns(m, 0, 1); v.Rs() != nil || len(v.Ys()) != 3 {
+		t.Fatalf("expected x and y only")
+	}
+	if v := Column(m.T(), 1); v.Ys() != nil || v.Xs()[2] != 6 {
+		t.Fatalf("expected the column of the transpose, got %v", v.Xs())
+	}
+
+	vec := mat.NewVecDense(3, []float64{4, 5, 6})
+	if xs := Vector(vec).Xs(); &xs[0] != &vec.RawVector().Data[0] {
+		t.Fatalf("expected the data of a VecDense not to be copied")
+	}
+	if xs := Vector(m.ColView(1)).Xs(); xs[2] != 30 {
+		t.Fatalf("unexpected column view: %v", xs)
+	}
+}
+
+func TestXYZs(t *testing.T) {
+	p := xyzs{{1, 2, 3}, {4, 5, 6}}
+	c := chartjs.Chart{Type: chartjs.Bubble}
+	c.AddDataset(chartjs.Dataset{Data: XYZs(p)})
+	if v := XYs(p); v.Xs()[1] != 4 || v.Ys()[1] != 5 {
+		t.Fatalf("unexpected values")
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling: %+v", err)
+	}
+	if !strings.Contains(string(b), `{"x":4.00,"y":5.00,"r":6.00}`) {
+		t.Fatalf("expected bubble points in %s", b)
+	}
+}
+
+func TestHeatmap(t *testing.T) {
+	c := chartjs.Chart{Type: chartjs.Heatmap}
+	c.AddDataset(chartjs.Dataset{Matrix: Heatmap{Matrix: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), Cols: []string{"a", "b"}}})
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling: %+v", err)
+	}
+	if !strings.Contains(string(b), `{"x":"b","y":"1","v":4.00}`) {
+		t.Fatalf("expected labelled cells in %s", b)
+	}
+}
+
+func TestStat(t *testing.T) {
+	x := []float64{1, 1.5, 2, 2.2, 3.5, 4}
+	dividers := []float64{0, 2, 4.5}
+	counts := stat.Histogram(nil, dividers, x, nil)
+	d, labels, err := Histogram(counts, dividers)
+	if err != nil {
+		t.Fatalf("error making histogram: %+v", err)
+	}
+	if xs := d.Data.Xs(); len(labels) != 2 || xs[0] != 2 || xs[1] != 4 {
+		t.Fatalf("unexpected histogram: %v %v", xs, labels)
+	}
+	if _, _, err := Histogram(counts, dividers[1:]); err == nil {
+		t.Fatalf("expected an error for mismatched dividers")
+	}
+
+	tpr, fpr, _ := stat.ROC(nil, []float64{0.1, 0.35, 0.4, 0.8}, []bool{false, true, false, true}, nil)
+	v := ROC(tpr, fpr)
+	if len(v.Xs()) != len(tpr) || v.Ys()[len(tpr)-1] != 1 {
+		t.Fatalf("unexpected roc: %v %v", v.Xs(), v.Ys())
+	}
+}
@@ -0,0 +1,109 @@
+package chartjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Handler is an http.Handler that serves a page of charts at its root and the JSON of each chart
+// at {i}/data.json. The page re-fetches the data so that it refreshes without a full reload.
+// Mount it at a path that ends in "/", e.g.:
+//
+//	http.Handle("/coverage/", http.StripPrefix("/coverage", &chartjs.Handler{Charts: f}))
+type Handler struct {
+	// Charts returns the charts to serve for the request.
+	Charts func(*http.Request) ([]Chart, error)
+	// Refresh is the interval at which the page re-fetches the data. If it is 0, the data is
+	// only re-fetched on demand with the refresh button or by calling refreshCharts().
+	Refresh time.Duration
+	// Options are sent to SaveCharts to render the page.
+	Options map[string]interface{}
+}
+
+const refreshJS = `
+function refreshCharts() {
+	charts.forEach(function(chart, i) {
+		$.getJSON(i + "/data.json", function(c) {
+			chart.data = c.data;
+			chart.update();
+		});
+	});
+}
+`
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	i := -1
+	if path != "" {
+		var err error
+		if !strings.HasSuffix(path, "/data.json") {
+			http.NotFound(w, r)
+			return
+		}
+		if i, err = strconv.Atoi(strings.TrimSuffix(path, "/data.json")); err != nil || i < 0 {
+			http.NotFound(w, r)
+			return
+		}
+	}
+	if h.Charts == nil {
+		http.Error(w, "chart: Handler has no Charts", http.StatusInternalServerError)
+		return
+	}
+	charts, err := h.Charts(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if i < 0 {
+		h.servePage(w, charts)
+		return
+	}
+	if i >= len(charts) {
+		http.NotFound(w, r)
+		return
+	}
+	b, err := json.Marshal(charts[i])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(b)
+}
+
+func (h *Handler) servePage(w http.ResponseWriter, charts []Chart) {
+	// SaveCharts fills in the map so each request gets its own copy.
+	tmap := make(map[string]interface{}, len(h.Options)+2)
+	for k, v := range h.Options {
+		tmap[k] = v
+	}
+	custom := refreshJS
+	if h.Refresh > 0 {
+		custom += fmt.Sprintf("setInterval(refreshCharts, %d);\n", h.Refresh/time.Millisecond)
+	}
+	if c, ok := tmap["custom"]; ok {
+		custom += fmt.Sprint(c)
+	}
+	tmap["custom"] = template.JS(custom)
+	button := template.HTML(`<button onclick="refreshCharts()">refresh</button>`)
+	if c, ok := tmap["customHTML"]; ok {
+		button += template.HTML(fmt.Sprint(c))
+	}
+	tmap["customHTML"] = button
+
+	var buf bytes.Buffer
+	if err := SaveCharts(&buf, tmap, charts...); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	buf.WriteTo(w)
+}
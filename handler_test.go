@@ -0,0 +1,89 @@
+package chartjs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	calls := 0
+	h := &Handler{
+		Charts: func(r *http.Request) ([]Chart, error) {
+			calls++
+			if r.URL.Query().Get("fail") != "" {
+				return nil, fmt.Errorf("failed")
+			}
+			xys := XY{X: []float64{1, 2, 3}, Y: []float64{float64(calls), 2, 3}}
+			c := Chart{Type: Line}
+			c.AddDataset(Dataset{Data: xys, Label: "calls"})
+			return []Chart{c, c}, nil
+		},
+		Refresh: 2 * time.Second,
+		Options: map[string]interface{}{"width": 300},
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("error getting %s: %+v", path, err)
+		}
+		defer resp.Body.Close()
+		b, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+
+	code, page := get("/")
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", code)
+	}
+	for _, s := range []string{"refreshCharts", "setInterval(refreshCharts, 2000)", "width:300px", "canvas1"} {
+		if !strings.Contains(page, s) {
+			t.Fatalf("expected %q in page", s)
+		}
+	}
+	if _, ok := h.Options["charts"]; ok {
+		t.Fatalf("expected Options to be left unchanged")
+	}
+
+	code, data := get("/1/data.json")
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", code)
+	}
+	var c struct {
+		Data struct {
+			Datasets []struct {
+				Data []struct{ X, Y float64 }
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		t.Fatalf("error unmarshaling data: %+v", err)
+	}
+	if c.Data.Datasets[0].Data[0].Y != 2 {
+		t.Fatalf("expected fresh data on each request: %s", data)
+	}
+
+	if code, _ := get("/2/data.json"); code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing chart, got %d", code)
+	}
+	if code, _ := get("/?fail=1"); code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 when Charts fails, got %d", code)
+	}
+	n := calls
+	if code, _ := get("/favicon.ico"); code != http.StatusNotFound || calls != n {
+		t.Fatalf("expected 404 without calling Charts, got %d", code)
+	}
+
+	h.Charts = nil
+	if code, body := get("/"); code != http.StatusInternalServerError || !strings.Contains(body, "no Charts") {
+		t.Fatalf("expected 500 without Charts, got %d: %s", code, body)
+	}
+}
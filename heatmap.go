@@ -0,0 +1,279 @@
+package chartjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+// MatrixJS holds the path to the hosted plugin that draws Heatmap charts.
+var MatrixJS = "https://unpkg.com/chartjs-chart-matrix@0.1.3/dist/chartjs-chart-matrix.min.js"
+
+// Matrix is the data for Heatmap charts. Cells that are NaN or infinite are transparent.
+type Matrix interface {
+	Dims() (rows, cols int)
+	At(i, j int) float64
+	// RowLabels and ColLabels name the rows and columns. If they return nil, the index is used.
+	RowLabels() []string
+	ColLabels() []string
+}
+
+// LabeledMatrix satisfies Matrix with a slice of rows.
+type LabeledMatrix struct {
+	Data [][]float64
+	Rows []string
+	Cols []string
+}
+
+// Dims satisfies the Matrix interface.
+func (m LabeledMatrix) Dims() (rows, cols int) {
+	if len(m.Data) == 0 {
+		return 0, 0
+	}
+	return len(m.Data), len(m.Data[0])
+}
+
+// check returns an error if the rows of m are not all the same length.
+func (m LabeledMatrix) check() error {
+	_, cols := m.Dims()
+	for i, row := range m.Data {
+		if len(row) != cols {
+			return fmt.Errorf("chart: row %d of the LabeledMatrix has %d values; expected %d", i, len(row), cols)
+		}
+	}
+	return nil
+}
+
+// At satisfies the Matrix interface.
+func (m LabeledMatrix) At(i, j int) float64 {
+	return m.Data[i][j]
+}
+
+// RowLabels satisfies the Matrix interface.
+func (m LabeledMatrix) RowLabels() []string {
+	return m.Rows
+}
+
+// ColLabels satisfies the Matrix interface.
+func (m LabeledMatrix) ColLabels() []string {
+	return m.Cols
+}
+
+// Colormap maps values in [0, 1] to colors by interpolating between its stops.
+type Colormap []types.RGBA
+
+var (
+	// Viridis is a perceptually uniform colormap from dark blue to yellow.
+	Viridis = Colormap{{68, 1, 84, 255}, {59, 82, 139, 255}, {33, 145, 140, 255}, {94, 201, 98, 255}, {253, 231, 37, 255}}
+	// Blues goes from white to dark blue.
+	Blues = Colormap{{247, 251, 255, 255}, {198, 219, 239, 255}, {107, 174, 214, 255}, {33, 113, 181, 255}, {8, 48, 107, 255}}
+	// RdBu is a diverging colormap from red through white to blue that suits correlations.
+	RdBu = Colormap{{178, 24, 43, 255}, {239, 138, 98, 255}, {253, 219, 199, 255}, {247, 247, 247, 255},
+		{209, 229, 240, 255}, {103, 169, 207, 255}, {33, 102, 172, 255}}
+)
+
+// At returns the color for t which is clamped to [0, 1].
+func (c Colormap) At(t float64) types.RGBA {
+	if len(c) == 1 || t <= 0 || math.IsNaN(t) {
+		return c[0]
+	}
+	if t >= 1 {
+		return c[len(c)-1]
+	}
+	f := t * float64(len(c)-1)
+	i := int(f)
+	f -= float64(i)
+	a, b := c[i], c[i+1]
+	mix := func(x, y uint8) uint8 { return uint8(float64(x) + f*(float64(y)-float64(x)) + 0.5) }
+	return types.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: mix(a.A, b.A)}
+}
+
+// ColorScale determines how the values of a Matrix are colored.
+type ColorScale struct {
+	// Colormap defaults to Viridis.
+	Colormap Colormap
+	// Min and Max set the range of values that spans the Colormap. If nil, the range of the data is used.
+	Min *float64
+	Max *float64
+	// Label is shown above the color scale legend.
+	Label string
+	// HideLegend hides the color scale legend drawn to the right of the chart.
+	HideLegend bool
+}
+
+// labels returns the labels or the indexes if they are not set.
+func labels(l []string, n int) []string {
+	if len(l) == n {
+		return l
+	}
+	l = make([]string, n)
+	for i := range l {
+		l[i] = strconv.Itoa(i)
+	}
+	return l
+}
+
+// finite reports whether v is neither NaN nor infinite.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// valueRange returns the range of the colors of the matrix. If no cell is finite, it is 0 to 1.
+func (s *ColorScale) valueRange(m Matrix) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	rows, cols := m.Dims()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if v := m.At(i, j); finite(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+	if lo > hi {
+		lo, hi = 0, 1
+	}
+	if s.Min != nil {
+		lo = *s.Min
+	}
+	if s.Max != nil {
+		hi = *s.Max
+	}
+	return lo, hi
+}
+
+// marshalMatrixJSON returns the data and the extra dataset fields for a Heatmap with one point per cell.
+// If bubble is true, the cells are sent as the points of a "bubble" chart.
+func marshalMatrixJSON(m Matrix, s *ColorScale, bubble bool, format string) (data, extra []byte, err error) {
+	if c, ok := m.(interface{ check() error }); ok {
+		if err := c.check(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if s == nil {
+		s = &ColorScale{}
+	}
+	cmap := s.Colormap
+	if len(cmap) == 0 {
+		cmap = Viridis
+	}
+	rows, cols := m.Dims()
+	rlabels, clabels := labels(m.RowLabels(), rows), labels(m.ColLabels(), cols)
+	lo, hi := s.valueRange(m)
+
+	dbuf := bytes.NewBuffer(make([]byte, 0, 32*rows*cols))
+	cbuf := bytes.NewBuffer(make([]byte, 0, 32*rows*cols))
+	dbuf.WriteRune('[')
+	cbuf.WriteRune('[')
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if i > 0 || j > 0 {
+				dbuf.WriteRune(',')
+				cbuf.WriteRune(',')
+			}
+			x, _ := json.Marshal(clabels[j])
+			y, _ := json.Marshal(rlabels[i])
+			v := m.At(i, j)
+			if !finite(v) {
+				v = math.NaN()
+			}
+			fmt.Fprintf(dbuf, `{"x":%s,"y":%s,"v":%s`, x, y, formatFloat(format, v))
+			if bubble {
+				// the radius is set to fill the cell when the chart is drawn.
+				dbuf.WriteString(`,"r":0`)
+			}
+			dbuf.WriteRune('}')
+			var c []byte
+			if math.IsNaN(v) {
+				c, err = types.RGBA{}.MarshalJSON()
+			} else if hi > lo {
+				c, err = cmap.At((v - lo) / (hi - lo)).MarshalJSON()
+			} else {
+				c, err = cmap.At(0.5).MarshalJSON()
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			cbuf.Write(c)
+		}
+	}
+	dbuf.WriteRune(']')
+	cbuf.WriteRune(']')
+
+	ebuf := bytes.NewBuffer(make([]byte, 0, cbuf.Len()+256))
+	ebuf.WriteString(`"backgroundColor":`)
+	ebuf.Write(cbuf.Bytes())
+	fmt.Fprintf(ebuf, `,"matrixSize":{"rows":%d,"cols":%d},`, rows, cols)
+	if bubble {
+		ebuf.WriteString(`"pointStyle":"rect",`)
+	}
+	if !s.HideLegend {
+		colors := make([]types.RGBA, 0, 16)
+		for k := 0; k < 16; k++ {
+			colors = append(colors, cmap.At(float64(k)/15))
+		}
+		legend, err := json.Marshal(struct {
+			Min    string       `json:"min"`
+			Max    string       `json:"max"`
+			Label  string       `json:"label,omitempty"`
+			Colors []types.RGBA `json:"colors"`
+		}{formatFloat(format, lo), formatFloat(format, hi), s.Label, colors})
+		if err != nil {
+			return nil, nil, err
+		}
+		ebuf.WriteString(`"colorScale":`)
+		ebuf.Write(legend)
+		ebuf.WriteRune(',')
+	}
+	return dbuf.Bytes(), ebuf.Bytes(), nil
+}
+
+// heatmapPlugin sizes the cells of Matrix datasets to fill the chart and draws the color scale legend.
+const heatmapPlugin = `
+Chart.plugins.register({
+	beforeInit: function(chart) {
+		chart.data.datasets.forEach(function(ds) {
+			if (!ds.colorScale) { return; }
+			var p = chart.options.layout.padding;
+			if (typeof p === 'number') {
+				p = chart.options.layout.padding = {top: p, right: p, bottom: p, left: p};
+			}
+			p.right = Math.max(p.right || 0, 70);
+		});
+	},
+	beforeDatasetsUpdate: function(chart) {
+		var a = chart.chartArea;
+		chart.data.datasets.forEach(function(ds) {
+			if (!ds.matrixSize) { return; }
+			var w = (a.right - a.left) / ds.matrixSize.cols - 1, h = (a.bottom - a.top) / ds.matrixSize.rows - 1;
+			ds.width = w;
+			ds.height = h;
+			ds.data.forEach(function(p) {
+				if (p.r !== undefined) { p.r = Math.min(w, h) / Math.SQRT2; }
+			});
+		});
+	},
+	afterDraw: function(chart) {
+		var ctx = chart.ctx, a = chart.chartArea, g = Chart.defaults.global;
+		chart.data.datasets.forEach(function(ds) {
+			var s = ds.colorScale;
+			if (!s) { return; }
+			var x = a.right + 15, w = 15, grad = ctx.createLinearGradient(0, a.bottom, 0, a.top);
+			s.colors.forEach(function(c, i) { grad.addColorStop(i / (s.colors.length - 1), c); });
+			ctx.save();
+			ctx.fillStyle = grad;
+			ctx.fillRect(x, a.top, w, a.bottom - a.top);
+			ctx.fillStyle = g.defaultFontColor;
+			ctx.font = Chart.helpers.fontString(g.defaultFontSize, 'normal', g.defaultFontFamily);
+			ctx.textBaseline = 'middle';
+			ctx.fillText(s.max, x + w + 4, a.top);
+			ctx.fillText(s.min, x + w + 4, a.bottom);
+			if (s.label) { ctx.fillText(s.label, x, a.top - 12); }
+			ctx.restore();
+		});
+	}
+});
+`
@@ -0,0 +1,127 @@
+package chartjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+func testMatrix() LabeledMatrix {
+	return LabeledMatrix{
+		Data: [][]float64{{1, 0.5, math.NaN()}, {0.5, 1, -0.2}},
+		Rows: []string{"a", "b"},
+		Cols: []string{"x", "y", "z"},
+	}
+}
+
+func TestColormap(t *testing.T) {
+	c := Colormap{{0, 0, 0, 255}, {200, 100, 0, 255}}
+	if got := c.At(0.5); got != (types.RGBA{R: 100, G: 50, B: 0, A: 255}) {
+		t.Fatalf("unexpected color: %v", got)
+	}
+	if c.At(-1) != c[0] || c.At(2) != c[1] {
+		t.Fatalf("expected colors to be clamped")
+	}
+}
+
+func TestHeatmap(t *testing.T) {
+	chart := Chart{Type: Heatmap}
+	chart.AddDataset(Dataset{Matrix: testMatrix(), ColorScale: &ColorScale{Colormap: RdBu, Label: "r"}})
+
+	b, err := json.Marshal(chart)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	var v struct {
+		Data struct {
+			Datasets []struct {
+				Data            []map[string]interface{}
+				BackgroundColor []string
+				ColorScale      struct{ Min, Max string }
+			}
+			XLabels []string
+			YLabels []string
+		}
+		Options struct {
+			Scales struct {
+				XAxes []struct{ Type string }
+			}
+		}
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("error unmarshaling chart: %+v", err)
+	}
+	d := v.Data.Datasets[0]
+	if len(d.Data) != 6 || len(d.BackgroundColor) != 6 {
+		t.Fatalf("expected a point and color per cell: %s", b)
+	}
+	if d.Data[2]["x"] != "z" || d.Data[2]["y"] != "a" || d.Data[2]["v"] != nil {
+		t.Fatalf("unexpected NaN cell: %v", d.Data[2])
+	}
+	if d.BackgroundColor[2] != "rgba(0, 0, 0, 0.000)" {
+		t.Fatalf("expected NaN cell to be transparent, got %s", d.BackgroundColor[2])
+	}
+	if d.ColorScale.Min != "-0.20" || d.ColorScale.Max != "1.00" {
+		t.Fatalf("unexpected color scale: %+v", d.ColorScale)
+	}
+	if strings.Join(v.Data.XLabels, ",") != "x,y,z" || strings.Join(v.Data.YLabels, ",") != "a,b" {
+		t.Fatalf("unexpected labels: %v %v", v.Data.XLabels, v.Data.YLabels)
+	}
+	if len(v.Options.Scales.XAxes) != 1 || v.Options.Scales.XAxes[0].Type != "category" {
+		t.Fatalf("expected a category x-axis")
+	}
+
+	var buf bytes.Buffer
+	if err := chart.SaveHTML(&buf, nil); err != nil {
+		t.Fatalf("error saving chart: %+v", err)
+	}
+	if !strings.Contains(buf.String(), MatrixJS) || !strings.Contains(buf.String(), "matrixSize") {
+		t.Fatalf("expected matrix plugin in html")
+	}
+}
+
+func TestHeatmapBubble(t *testing.T) {
+	chart := Chart{Type: Bubble}
+	chart.AddDataset(Dataset{Matrix: testMatrix()})
+
+	b, err := json.Marshal(chart)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	if !strings.Contains(string(b), `"pointStyle":"rect"`) || !strings.Contains(string(b), `"r":0`) {
+		t.Fatalf("expected rect bubbles in %s", b)
+	}
+	if len(chart.scripts()) != 0 {
+		t.Fatalf("expected no matrix plugin for the bubble fallback")
+	}
+
+	chart.Type = Bar
+	if _, err := json.Marshal(chart); err == nil {
+		t.Fatalf("expected error with Matrix on a bar chart")
+	}
+}
+
+func TestHeatmapBadValues(t *testing.T) {
+	chart := Chart{Type: Heatmap}
+	chart.AddDataset(Dataset{Matrix: LabeledMatrix{Data: [][]float64{{1, 2}, {3}}}})
+	if _, err := json.Marshal(chart); err == nil || !strings.Contains(err.Error(), "row 1") {
+		t.Fatalf("expected error with a ragged matrix, got %v", err)
+	}
+
+	chart = Chart{Type: Heatmap}
+	chart.AddDataset(Dataset{Matrix: LabeledMatrix{Data: [][]float64{{math.NaN(), math.Inf(1)}}}})
+	b, err := json.Marshal(chart)
+	if err != nil {
+		t.Fatalf("error marshaling chart without finite values: %+v", err)
+	}
+	if !strings.Contains(string(b), `"min":"0.00","max":"1.00"`) {
+		t.Fatalf("expected a color scale from 0 to 1 in %s", b)
+	}
+	if strings.Contains(string(b), "Inf") {
+		t.Fatalf("expected infinite cells to be null in %s", b)
+	}
+}
@@ -0,0 +1,19 @@
+// Package nice chooses the round numbers used for the ticks of an axis. It is shared by the ticks
+// of the axes with Breaks and the render package so that both place them the same way.
+package nice
+
+import "math"
+
+// Step returns 1, 2 or 5 times a power of 10 near x.
+func Step(x float64) float64 {
+	p := math.Pow(10, math.Floor(math.Log10(x)))
+	switch f := x / p; {
+	case f < 1.5:
+		return p
+	case f < 3:
+		return 2 * p
+	case f < 7:
+		return 5 * p
+	}
+	return 10 * p
+}
@@ -0,0 +1,337 @@
+package chartjs
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// logSafety determines how a Log axis shows values that are zero or negative.
+type logSafety int
+
+const (
+	_ logSafety = iota
+	// LogDrop drops the values that are zero or negative. Chart.Marshal returns a Warning for
+	// each dataset that had values dropped.
+	LogDrop
+	// LogPseudocount adds LogOptions.Pseudocount to every value so that zeros can be shown.
+	LogPseudocount
+)
+
+// LogOptions sets the ticks of a Log or Symlog axis and makes a Log axis safe for values that
+// aren't positive. The ticks are powers of 10 and are set by a plugin in the browser.
+type LogOptions struct {
+	Safety logSafety
+	// Pseudocount is added to the values with LogPseudocount. It is 1 if it is 0.
+	Pseudocount float64
+	// Minor adds ticks at 2 and 5 times each power of 10.
+	Minor bool
+	// Constant is the distance from zero within which a Symlog axis is close to linear.
+	// It is 1 if it is 0.
+	Constant float64
+}
+
+// logOptions returns the options of a Log axis with LogOptions or of a Symlog axis with the
+// defaults filled in. It returns false for other axes.
+func (a Axis) logOptions() (LogOptions, bool) {
+	if a.Type != Symlog && (a.Type != Log || a.Log == nil) {
+		return LogOptions{}, false
+	}
+	var o LogOptions
+	if a.Log != nil {
+		o = *a.Log
+	}
+	if o.Pseudocount == 0 {
+		o.Pseudocount = 1
+	}
+	if o.Constant <= 0 {
+		o.Constant = 1
+	}
+	return o, true
+}
+
+// transforms returns true if Transform changes the values of the axis.
+func (a Axis) transforms() bool {
+	return a.Type == Symlog || (a.Type == Log && a.Log != nil && a.Log.Safety != 0) || len(a.breaks()) > 0
+}
+
+// Transform returns v as it is placed on the axis: with the Pseudocount added for LogPseudocount,
+// NaN if it is dropped by LogDrop, mapped to sign(v)*log10(1+|v|/Constant) on a Symlog axis, or
+// moved down by the Breaks below it and NaN within one. Values are unchanged on other axes.
+func (a Axis) Transform(v float64) float64 {
+	if b := a.breaks(); b != nil {
+		return removeBreaks(b, v)
+	}
+	o, ok := a.logOptions()
+	switch {
+	case !ok:
+	case a.Type == Symlog:
+		return math.Copysign(math.Log10(1+math.Abs(v)/o.Constant), v)
+	case o.Safety == LogPseudocount:
+		return v + o.Pseudocount
+	case o.Safety == LogDrop && v <= 0:
+		return math.NaN()
+	}
+	return v
+}
+
+// untransform returns the original value at v on a Symlog axis, a Log axis with LogPseudocount or
+// one with Breaks.
+func (a Axis) untransform(v float64) float64 {
+	if b := a.breaks(); b != nil {
+		return restoreBreaks(b, v)
+	}
+	o, ok := a.logOptions()
+	switch {
+	case !ok:
+	case a.Type == Symlog:
+		return math.Copysign(o.Constant*(math.Pow(10, math.Abs(v))-1), v)
+	case o.Safety == LogPseudocount:
+		return v - o.Pseudocount
+	}
+	return v
+}
+
+// Ticks returns the ticks that the plugins set on a Log axis with LogOptions, a Symlog axis or
+// an axis with Breaks, that cover min to max, which are values on the axis as returned by
+// Transform. The labels are the original values. It returns nil for other axes.
+func (a Axis) Ticks(min, max float64) ([]float64, []string) {
+	if b := a.breaks(); b != nil {
+		return breakTicks(b, min, max)
+	}
+	o, ok := a.logOptions()
+	if !ok || !(min <= max) {
+		return nil, nil
+	}
+	var vals []float64
+	switch {
+	case a.Type == Log && o.Safety == LogPseudocount:
+		// the ticks are at 0 and the powers of 10 of the values before the Pseudocount is added.
+		p := o.Pseudocount
+		vals = append([]float64{0}, powers(p, math.Max(a.untransform(max), p), o.Minor)...)
+	case a.Type == Log:
+		vals = powers(min, max, o.Minor)
+	default:
+		c := o.Constant
+		lo, hi := a.untransform(min), a.untransform(max)
+		if lo < 0 {
+			n := powers(c, math.Max(-lo, c), o.Minor)
+			for i := len(n) - 1; i >= 0; i-- {
+				vals = append(vals, -n[i])
+			}
+		}
+		vals = append(vals, 0)
+		if hi > 0 {
+			vals = append(vals, powers(c, math.Max(hi, c), o.Minor)...)
+		}
+	}
+	ticks := make([]float64, len(vals))
+	labels := make([]string, len(vals))
+	for i, v := range vals {
+		ticks[i] = a.Transform(v)
+		labels[i] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return ticks, labels
+}
+
+// powers returns the powers of 10, and 2 and 5 times them if minor, from the power at or below lo
+// to the one above hi.
+func powers(lo, hi float64, minor bool) []float64 {
+	if !(lo > 0) || hi < lo || math.IsInf(hi, 0) {
+		return nil
+	}
+	mults := []float64{1}
+	if minor {
+		mults = []float64{1, 2, 5}
+	}
+	e0, e1 := math.Floor(math.Log10(lo)), math.Ceil(math.Log10(hi))
+	if e1 == e0 {
+		e1++
+	}
+	var vals []float64
+	for e := e0; e <= e1; e++ {
+		for _, m := range mults {
+			if e == e1 && m > 1 {
+				break
+			}
+			vals = append(vals, m*math.Pow(10, e))
+		}
+	}
+	return vals
+}
+
+// Warning describes values of a dataset that aren't shown because they aren't positive on a Log
+// axis with LogDrop or are within a Break.
+type Warning struct {
+	// Dataset is the index of the dataset and Axis is the ID of the axis that dropped Dropped values.
+	Dataset int
+	Axis    string
+	Dropped int
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("chart: dataset %d: dropped %d values that can't be placed on axis %q", w.Dataset, w.Dropped, w.Axis)
+}
+
+// transformed holds Values placed on Log or Symlog axes.
+type transformed struct {
+	xs, ys, rs []float64
+}
+
+func (t *transformed) Xs() []float64 { return t.xs }
+func (t *transformed) Ys() []float64 { return t.ys }
+func (t *transformed) Rs() []float64 { return t.rs }
+
+// transformedErrors holds ErrorValues placed on Log or Symlog axes.
+type transformedErrors struct {
+	transformed
+	lo, hi []float64
+}
+
+func (t *transformedErrors) YLow() []float64  { return t.lo }
+func (t *transformedErrors) YHigh() []float64 { return t.hi }
+
+// transformedXErrors also holds the XErrorValues.
+type transformedXErrors struct {
+	transformedErrors
+	xlo, xhi []float64
+}
+
+func (t *transformedXErrors) XLow() []float64  { return t.xlo }
+func (t *transformedXErrors) XHigh() []float64 { return t.xhi }
+
+// transformValues returns v with Transform of the axes applied and the number of values that were
+// dropped from each. Points with an x that is dropped are removed; a y that is dropped is missing.
+func transformValues(v Values, x, y Axis) (Values, int, int) {
+	if !x.transforms() && !y.transforms() {
+		return v, 0, 0
+	}
+	apply := func(a Axis, vals []float64, dropped *int) []float64 {
+		if vals == nil {
+			return nil
+		}
+		out := make([]float64, len(vals))
+		for i, f := range vals {
+			out[i] = a.Transform(f)
+			if math.IsNaN(out[i]) && !math.IsNaN(f) {
+				*dropped++
+			}
+		}
+		return out
+	}
+	var xd, yd int
+	xs, ys := v.Xs(), v.Ys()
+	if len(xs) == 0 || len(ys) == 0 {
+		// a single set of values is placed on the y axis.
+		if len(ys) == 0 {
+			ys = xs
+		}
+		t := transformed{ys: apply(y, ys, &yd)}
+		if ev, ok := v.(ErrorValues); ok {
+			var n int
+			return &transformedErrors{transformed: t, lo: apply(y, ev.YLow(), &n), hi: apply(y, ev.YHigh(), &n)}, 0, yd
+		}
+		return &t, 0, yd
+	}
+	t := transformed{xs: apply(x, xs, &xd), ys: apply(y, ys, &yd), rs: v.Rs()}
+	var e *transformedErrors
+	var xe *transformedXErrors
+	if ev, ok := v.(ErrorValues); ok {
+		var n int
+		e = &transformedErrors{lo: apply(y, ev.YLow(), &n), hi: apply(y, ev.YHigh(), &n)}
+		if xv, ok := v.(XErrorValues); ok {
+			xe = &transformedXErrors{xlo: apply(x, xv.XLow(), &n), xhi: apply(x, xv.XHigh(), &n)}
+		}
+	}
+	if xd > 0 {
+		// points can't be sent without an x so they are removed.
+		keep := func(vals []float64) []float64 {
+			if len(vals) != len(t.xs) {
+				return vals
+			}
+			var out []float64
+			for i, f := range vals {
+				if !math.IsNaN(t.xs[i]) {
+					out = append(out, f)
+				}
+			}
+			return out
+		}
+		if e != nil {
+			e.lo, e.hi = keep(e.lo), keep(e.hi)
+		}
+		if xe != nil {
+			xe.xlo, xe.xhi = keep(xe.xlo), keep(xe.xhi)
+		}
+		t.ys, t.rs = keep(t.ys), keep(t.rs)
+		t.xs = keep(t.xs)
+	}
+	switch {
+	case xe != nil:
+		xe.transformedErrors = transformedErrors{transformed: t, lo: e.lo, hi: e.hi}
+		return xe, xd, yd
+	case e != nil:
+		e.transformed = t
+		return e, xd, yd
+	}
+	return &t, xd, yd
+}
+
+// logTicksPlugin sets the ticks of the axes that Axis.MarshalJSON sends with "logTicks" to the
+// powers of 10 between the limits of the axis, as Axis.Ticks does, and shows the original values
+// in tooltips.
+const logTicksPlugin = `
+Chart.plugins.register({
+	beforeInit: function(chart) {
+		var s = chart.options.scales || {}, any = false;
+		(s.xAxes || []).concat(s.yAxes || []).forEach(function(a) {
+			var o = a.logTicks;
+			if (!o) { return; }
+			var c = o.symlog, p = o.pseudocount;
+			var to = function(v) { return c ? Math.sign(v) * Math.log10(1 + Math.abs(v) / c) : v + p; };
+			var from = function(v) { return c ? Math.sign(v) * c * (Math.pow(10, Math.abs(v)) - 1) : v - p; };
+			if (c || p) {
+				any = true;
+				a.logFrom = from;
+			}
+			var powers = function(lo, hi) {
+				var t = [], e0 = Math.floor(Math.log10(lo)), e1 = Math.ceil(Math.log10(hi));
+				if (!(lo > 0) || !isFinite(hi) || hi < lo) { return t; }
+				if (e1 === e0) { e1++; }
+				for (var e = e0; e <= e1; e++) {
+					(o.minor && e < e1 ? [1, 2, 5] : [1]).forEach(function(m) { t.push(m * Math.pow(10, e)); });
+				}
+				return t;
+			};
+			a.afterBuildTicks = function(scale) {
+				var min = Math.min(scale.min, scale.max), max = Math.max(scale.min, scale.max);
+				var lo = from(min), hi = from(max), t = powers(lo, hi);
+				if (p) {
+					t = [0].concat(powers(p, Math.max(hi, p)));
+				} else if (c) {
+					t = [0];
+					if (lo < 0) { t = powers(c, Math.max(-lo, c)).map(function(v) { return -v; }).reverse().concat(t); }
+					if (hi > 0) { t = t.concat(powers(c, Math.max(hi, c))); }
+				}
+				var eps = (max - min) * 1e-9;
+				t = t.map(to).filter(function(v) { return v >= min - eps && v <= max + eps; });
+				return t.length ? t : undefined;
+			};
+			a.ticks = a.ticks || {};
+			a.ticks.callback = function(v) { return String(parseFloat(from(v).toPrecision(6))); };
+		});
+		if (!any) { return; }
+		var cb = chart.options.tooltips.callbacks, label = cb.label, title = cb.title;
+		var format = function(f, v) { return String(parseFloat(f(+v).toPrecision(6))); };
+		var original = function(item) {
+			var meta = chart.getDatasetMeta(item.datasetIndex), x = chart.scales[meta.xAxisID], y = chart.scales[meta.yAxisID];
+			item = Chart.helpers.extend({}, item);
+			if (y && y.options.logFrom) { item.yLabel = item.value = format(y.options.logFrom, item.yLabel); }
+			if (x && x.options.logFrom) { item.xLabel = item.label = format(x.options.logFrom, item.xLabel); }
+			return item;
+		};
+		cb.label = function(item, data) { return label.call(this, original(item), data); };
+		cb.title = function(items, data) { return title.call(this, items.map(original), data); };
+	}
+});
+`
@@ -0,0 +1,126 @@
+package chartjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	pc := Axis{Type: Log, Log: &LogOptions{Safety: LogPseudocount}}
+	if v := pc.Transform(0); v != 1 {
+		t.Fatalf("expected the pseudocount to be added, got %v", v)
+	}
+	drop := Axis{Type: Log, Log: &LogOptions{Safety: LogDrop}}
+	if v := drop.Transform(-1); !math.IsNaN(v) {
+		t.Fatalf("expected a negative value to be dropped, got %v", v)
+	}
+	sym := Axis{Type: Symlog}
+	if v := sym.Transform(-99); v != -2 {
+		t.Fatalf("expected symlog(-99) to be -2, got %v", v)
+	}
+	if v := sym.untransform(sym.Transform(42)); math.Abs(v-42) > 1e-9 {
+		t.Fatalf("expected untransform to invert Transform, got %v", v)
+	}
+	if v := (Axis{Type: Log}).Transform(-1); v != -1 {
+		t.Fatalf("expected no change without LogOptions, got %v", v)
+	}
+}
+
+func TestLogTicks(t *testing.T) {
+	_, labels := Axis{Type: Log, Log: &LogOptions{Minor: true}}.Ticks(3, 300)
+	if got := strings.Join(labels, " "); got != "1 2 5 10 20 50 100 200 500 1000" {
+		t.Fatalf("unexpected log ticks: %s", got)
+	}
+	a := Axis{Type: Symlog}
+	vals, labels := a.Ticks(a.Transform(-50), a.Transform(500))
+	if got := strings.Join(labels, " "); got != "-100 -10 -1 0 1 10 100 1000" {
+		t.Fatalf("unexpected symlog ticks: %s", got)
+	}
+	if vals[3] != 0 || vals[5] != a.Transform(10) {
+		t.Fatalf("expected the ticks to be placed on the axis, got %v", vals)
+	}
+	p := Axis{Type: Log, Log: &LogOptions{Safety: LogPseudocount}}
+	vals, labels = p.Ticks(p.Transform(0), p.Transform(500))
+	if got := strings.Join(labels, " "); got != "0 1 10 100 1000" || vals[0] != 1 || vals[2] != 11 {
+		t.Fatalf("expected the ticks of a pseudocount axis to be labelled with the original values, got %s at %v", got, vals)
+	}
+	if vals, _ := (Axis{Type: Linear}).Ticks(0, 1); vals != nil {
+		t.Fatalf("expected no ticks for a linear axis")
+	}
+}
+
+func TestLogDrop(t *testing.T) {
+	c := Chart{Type: Line}
+	c.AddDataset(Dataset{Data: XY{X: []float64{0, 1, 2}, Y: []float64{0, 10, 100}}})
+	c.AddDataset(Dataset{Type: Bar, Data: Ys{0, 1}})
+	c.AddXAxis(Axis{Type: Log, Log: &LogOptions{Safety: LogDrop}})
+	c.AddYAxis(Axis{Type: Log, Log: &LogOptions{Safety: LogDrop, Minor: true}})
+
+	b, warnings, err := c.Marshal()
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	if len(warnings) != 3 || warnings[0] != (Warning{Dataset: 0, Axis: "xaxis0", Dropped: 1}) || warnings[2].Dataset != 1 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+	if !strings.Contains(warnings[0].String(), "dropped 1 values") {
+		t.Fatalf("unexpected warning: %s", warnings[0])
+	}
+	if !json.Valid(b) {
+		t.Fatalf("invalid json: %s", b)
+	}
+	for _, want := range []string{`"data":[{"x":1.00,"y":10.00},{"x":2.00,"y":100.00}]`, `"data":[null,1.00]`,
+		`"logTicks":{"minor":true,"symlog":0,"pseudocount":0}`} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("expected %s in %s", want, b)
+		}
+	}
+	var buf bytes.Buffer
+	if err := c.SaveHTML(&buf, nil); err != nil {
+		t.Fatalf("error saving chart: %+v", err)
+	}
+	if !strings.Contains(buf.String(), "afterBuildTicks") {
+		t.Fatalf("expected the log ticks plugin in html")
+	}
+}
+
+func TestSymlog(t *testing.T) {
+	c := Chart{Type: Line}
+	c.AddDataset(Dataset{Data: sinErr()})
+	c.AddYAxis(Axis{Type: Symlog, Log: &LogOptions{Constant: 0.1}})
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	for _, want := range []string{`"type":"linear"`, `"logTicks":{"minor":false,"symlog":0.1,"pseudocount":0}`, `"errorBars":[{"yMin":-0.48,"yMax":0.48}`} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("expected %s in %s", want, b)
+		}
+	}
+}
+
+func TestTransformErrorsByIndex(t *testing.T) {
+	v := xyerr{XY: XY{Y: []float64{1, 2, 3}}, lo: []float64{0, 1, 2}, hi: []float64{2, 3, 4}}
+	for _, a := range []Axis{
+		{Type: Log, Log: &LogOptions{Safety: LogPseudocount}},
+		{Type: Linear, Breaks: []Break{{From: 10, To: 20}}},
+	} {
+		c := Chart{Type: Bar}
+		c.AddDataset(Dataset{Data: v})
+		c.AddYAxis(a)
+		b, err := json.Marshal(c)
+		if err != nil {
+			t.Fatalf("error marshaling chart: %+v", err)
+		}
+		want := `"errorBars":[{"yMin":0.00,"yMax":2.00}`
+		if a.Type == Log {
+			want = `"errorBars":[{"yMin":1.00,"yMax":3.00}`
+		}
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("expected %s in %s", want, b)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package chartjs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+// RadialScale is the single scale of Radar and PolarArea charts. It is set as Options.Scale
+// rather than in Options.Scales and is sent with the Radial type.
+type RadialScale struct {
+	Display types.Bool `json:"display,omitempty"`
+	// AngleLines are the lines from the center to each of the Labels of a Radar chart.
+	AngleLines *AngleLineOptions `json:"angleLines,omitempty"`
+	// GridLines are the rings at each tick. They are polygons on a Radar chart unless Circular.
+	GridLines *GridLineOptions `json:"gridLines,omitempty"`
+	// PointLabels are the Labels shown around a Radar chart.
+	PointLabels *PointLabelOptions `json:"pointLabels,omitempty"`
+	Tick        *RadialTick        `json:"ticks,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (s RadialScale) MarshalJSON() ([]byte, error) {
+	// avoid recursion by creating an alias.
+	type alias RadialScale
+	return json.Marshal(struct {
+		Type axisType `json:"type"`
+		alias
+	}{Radial, alias(s)})
+}
+
+// AngleLineOptions corresponds to the angleLines of a RadialScale.
+type AngleLineOptions struct {
+	Display          types.Bool  `json:"display,omitempty"`
+	Color            *types.RGBA `json:"color,omitempty"`
+	LineWidth        float64     `json:"lineWidth,omitempty"`
+	BorderDash       []float64   `json:"borderDash,omitempty"`
+	BorderDashOffset float64     `json:"borderDashOffset,omitempty"`
+}
+
+// PointLabelOptions corresponds to the pointLabels of a RadialScale.
+type PointLabelOptions struct {
+	Display    types.Bool  `json:"display,omitempty"`
+	FontColor  *types.RGBA `json:"fontColor,omitempty"`
+	FontFamily string      `json:"fontFamily,omitempty"`
+	FontSize   int         `json:"fontSize,omitempty"`
+	FontStyle  string      `json:"fontStyle,omitempty"`
+}
+
+// RadialTick sets the range and the labels of the ticks of a RadialScale.
+type RadialTick struct {
+	Min float64 `json:"min,omitempty"`
+	Max float64 `json:"max,omitempty"`
+	// SuggestedMin and SuggestedMax extend the range only if the data doesn't.
+	SuggestedMin  float64    `json:"suggestedMin,omitempty"`
+	SuggestedMax  float64    `json:"suggestedMax,omitempty"`
+	StepSize      float64    `json:"stepSize,omitempty"`
+	MaxTicksLimit int        `json:"maxTicksLimit,omitempty"`
+	BeginAtZero   types.Bool `json:"beginAtZero,omitempty"`
+	Display       types.Bool `json:"display,omitempty"`
+	// ShowLabelBackdrop draws the BackdropColor behind each label (this is the default).
+	ShowLabelBackdrop types.Bool  `json:"showLabelBackdrop,omitempty"`
+	BackdropColor     *types.RGBA `json:"backdropColor,omitempty"`
+}
+
+// radial returns true for the chart types that are drawn on a RadialScale.
+func (c chartType) radial() bool {
+	return c == Radar || c == PolarArea
+}
+
+// validRadial returns an error if a RadialScale or a Radial axis is used by a chart that isn't a
+// Radar or PolarArea chart, or if one of those has x or y axes.
+func (c Chart) validRadial() error {
+	scales := append(append([]Axis{}, c.Options.Scales.XAxes...), c.Options.Scales.YAxes...)
+	for _, a := range scales {
+		if a.Type == Radial {
+			return fmt.Errorf("chart: axis %q: a Radial axis is set with Options.Scale", a.ID)
+		}
+	}
+	if !c.Type.radial() {
+		if c.Options.Scale != nil {
+			return fmt.Errorf("chart: a RadialScale is only used by Radar and PolarArea charts")
+		}
+		return nil
+	}
+	if len(scales) > 0 {
+		return fmt.Errorf("chart: Radar and PolarArea charts have no x or y axes")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package chartjs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+func TestRadialScale(t *testing.T) {
+	c := Chart{Type: Radar}
+	c.Data.Labels = []string{"a", "b", "c"}
+	c.AddDataset(Dataset{Data: Ys{1, 2, 3}, Label: "r"})
+	c.Options.Scale = &RadialScale{AngleLines: &AngleLineOptions{Color: &types.RGBA{0, 0, 0, 255}},
+		GridLines: &GridLineOptions{Circular: True}, PointLabels: &PointLabelOptions{FontSize: 14},
+		Tick: &RadialTick{SuggestedMax: 5, BeginAtZero: True}}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling chart: %+v", err)
+	}
+	for _, want := range []string{`"type":"radar"`, `"data":[1.00,2.00,3.00]`, `"scale":{"type":"radialLinear"`,
+		`"angleLines":{"color":"rgba(0, 0, 0, 1.000)"}`, `"gridLines":{"circular":true}`,
+		`"pointLabels":{"fontSize":14}`, `"ticks":{"suggestedMax":5,"beginAtZero":true}`} {
+		if !strings.Contains(string(b), want) {
+			t.Fatalf("expected %s in %s", want, b)
+		}
+	}
+
+	c.Type = PolarArea
+	if _, err := json.Marshal(c); err != nil {
+		t.Fatalf("unexpected error for a PolarArea chart: %+v", err)
+	}
+	c.AddYAxis(Axis{Type: Linear})
+	if _, err := json.Marshal(c); err == nil {
+		t.Fatalf("expected an error for a PolarArea chart with a y axis")
+	}
+
+	l := Chart{Type: Line}
+	l.AddDataset(Dataset{Data: Ys{1, 2, 3}})
+	l.Options.Scale = &RadialScale{}
+	if _, err := json.Marshal(l); err == nil {
+		t.Fatalf("expected an error for a RadialScale on a Line chart")
+	}
+	l.Options.Scale = nil
+	l.AddYAxis(Axis{Type: Radial})
+	if _, err := json.Marshal(l); err == nil {
+		t.Fatalf("expected an error for a Radial axis in Scales")
+	}
+
+	m := Chart{Type: Radar}
+	m.AddDataset(Dataset{Data: Ys{1, 2}, Type: Line})
+	if _, err := json.Marshal(m); err == nil {
+		t.Fatalf("expected an error for a Line dataset on a Radar chart")
+	}
+	m.Data.Datasets[0] = Dataset{Data: XY{X: []float64{1, 2}, Y: []float64{3, 4}}}
+	if _, err := json.Marshal(m); err == nil {
+		t.Fatalf("expected an error for x and y values on a Radar chart")
+	}
+}
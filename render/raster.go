@@ -0,0 +1,166 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	chartjs "github.com/brentp/go-chartjs"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// PNG writes the chart as a PNG image of the given size in pixels.
+func PNG(w io.Writer, c chartjs.Chart, width, height int) error {
+	img, err := Image(c, width, height)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// Image draws the chart to an image of the given size in pixels.
+func Image(c chartjs.Chart, width, height int) (*image.RGBA, error) {
+	ch, err := newChart(c, width, height)
+	if err != nil {
+		return nil, err
+	}
+	r := &raster{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+	ch.draw(r)
+	return r.img, nil
+}
+
+type raster struct {
+	img *image.RGBA
+}
+
+// area returns twice the signed area of the polygon.
+func area(pts []pt) float64 {
+	var a float64
+	for i, p := range pts {
+		q := pts[(i+1)%len(pts)]
+		a += p.x*q.y - q.x*p.y
+	}
+	return a
+}
+
+// fill fills the union of the polygons with c. The polygons must not have opposite
+// orientations where they overlap.
+func (r *raster) fill(polys [][]pt, c color.NRGBA) {
+	if c.A == 0 {
+		return
+	}
+	b := r.img.Bounds()
+	z := vector.NewRasterizer(b.Dx(), b.Dy())
+	for _, p := range polys {
+		if len(p) < 3 {
+			continue
+		}
+		z.MoveTo(float32(p[0].x), float32(p[0].y))
+		for _, q := range p[1:] {
+			z.LineTo(float32(q.x), float32(q.y))
+		}
+		z.ClosePath()
+	}
+	z.Draw(r.img, b, image.NewUniform(c), image.Point{})
+}
+
+// disc returns a polygon approximating a circle.
+func disc(c pt, r float64) []pt {
+	n := int(math.Max(12, 2*r))
+	pts := make([]pt, n)
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		pts[i] = pt{c.x + r*math.Cos(a), c.y + r*math.Sin(a)}
+	}
+	return pts
+}
+
+// outline returns the polygons that cover a line of width w through pts with round joins.
+func outline(pts []pt, w float64) [][]pt {
+	var polys [][]pt
+	h := w / 2
+	for i := 1; i < len(pts); i++ {
+		a, b := pts[i-1], pts[i]
+		dx, dy := b.x-a.x, b.y-a.y
+		l := math.Hypot(dx, dy)
+		if l == 0 {
+			continue
+		}
+		nx, ny := -dy/l*h, dx/l*h
+		polys = append(polys, []pt{{a.x + nx, a.y + ny}, {b.x + nx, b.y + ny}, {b.x - nx, b.y - ny}, {a.x - nx, a.y - ny}})
+		if i > 1 && w > 1.5 {
+			polys = append(polys, disc(a, h))
+		}
+	}
+	// orient the polygons alike so that their overlaps are filled once.
+	for _, p := range polys {
+		if area(p) < 0 {
+			for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+				p[i], p[j] = p[j], p[i]
+			}
+		}
+	}
+	return polys
+}
+
+func (r *raster) polyline(pts []pt, s stroke) {
+	if s.width <= 0 || len(pts) < 2 {
+		return
+	}
+	if len(s.dash) == 0 {
+		r.fill(outline(pts, s.width), s.color)
+		return
+	}
+	var polys [][]pt
+	for _, d := range dashes(pts, s.dash, s.dashOffset) {
+		polys = append(polys, outline(d, s.width)...)
+	}
+	r.fill(polys, s.color)
+}
+
+func (r *raster) polygon(pts []pt, fill color.NRGBA) {
+	r.fill([][]pt{pts}, fill)
+}
+
+func (r *raster) circle(c pt, rad float64, fill color.NRGBA, s stroke) {
+	d := disc(c, rad)
+	r.fill([][]pt{d}, fill)
+	r.polyline(append(d, d[0]), s)
+}
+
+func (r *raster) text(p pt, t string, c color.NRGBA, a anchor, vertical bool) {
+	face := basicfont.Face7x13
+	w := font.MeasureString(face, t).Ceil()
+	var ax int
+	switch a {
+	case middle:
+		ax = w / 2
+	case end:
+		ax = w
+	}
+	if !vertical {
+		d := &font.Drawer{Dst: r.img, Src: image.NewUniform(c), Face: face,
+			Dot: fixed.P(int(p.x+0.5)-ax, int(p.y+0.5))}
+		d.DrawString(t)
+		return
+	}
+	// draw the text horizontally and then rotate it counter-clockwise into place.
+	h, ascent := face.Height, face.Ascent
+	tmp := image.NewRGBA(image.Rect(0, 0, w, h))
+	d := &font.Drawer{Dst: tmp, Src: image.NewUniform(c), Face: face, Dot: fixed.P(0, ascent)}
+	d.DrawString(t)
+	rot := image.NewRGBA(image.Rect(0, 0, h, w))
+	for ty := 0; ty < h; ty++ {
+		for tx := 0; tx < w; tx++ {
+			rot.SetRGBA(ty, w-1-tx, tmp.RGBAAt(tx, ty))
+		}
+	}
+	x0, y0 := int(p.x+0.5)-ascent, int(p.y+0.5)+ax-w+1
+	draw.Draw(r.img, image.Rect(x0, y0, x0+h, y0+w), rot, image.Point{}, draw.Over)
+}
@@ -0,0 +1,1150 @@
+// Package render draws a chartjs.Chart to SVG or PNG without a browser so that charts can be
+// included in reports and emails where javascript doesn't run.
+//
+// Line, Bar and Bubble datasets are drawn along with the axes, legend and title. The output is
+// not identical to Chart.js but it follows the styling of each Dataset.
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+
+	chartjs "github.com/brentp/go-chartjs"
+	"github.com/brentp/go-chartjs/internal/nice"
+	"github.com/brentp/go-chartjs/types"
+)
+
+// pt is a point in pixels with y increasing downwards.
+type pt struct {
+	x, y float64
+}
+
+// stroke describes how a line is drawn.
+type stroke struct {
+	color color.NRGBA
+	width float64
+	// dash is the lengths of alternating dashes and gaps starting dashOffset into the pattern.
+	dash       []float64
+	dashOffset float64
+	// cap and join are the SVG names of the styles. They are round if empty.
+	cap, join string
+}
+
+// dashes splits pts into the dashes of the pattern.
+func dashes(pts []pt, dash []float64, offset float64) [][]pt {
+	var sum float64
+	for _, d := range dash {
+		if d < 0 {
+			return [][]pt{pts}
+		}
+		sum += d
+	}
+	if sum <= 0 || len(pts) < 2 {
+		return [][]pt{pts}
+	}
+	// an odd pattern is repeated as in the canvas API.
+	if len(dash)%2 == 1 {
+		dash = append(append([]float64{}, dash...), dash...)
+		sum *= 2
+	}
+	off := math.Mod(offset, sum)
+	if off < 0 {
+		off += sum
+	}
+	k, left := 0, dash[0]
+	for off >= left {
+		off -= left
+		k = (k + 1) % len(dash)
+		left = dash[k]
+	}
+	left -= off
+
+	var out [][]pt
+	var cur []pt
+	if k%2 == 0 {
+		cur = []pt{pts[0]}
+	}
+	for i := 1; i < len(pts); i++ {
+		a, b := pts[i-1], pts[i]
+		l := math.Hypot(b.x-a.x, b.y-a.y)
+		if l == 0 {
+			continue
+		}
+		t := 0.0
+		for l-t >= left {
+			t += left
+			p := pt{a.x + (b.x-a.x)*t/l, a.y + (b.y-a.y)*t/l}
+			if k%2 == 0 {
+				out = append(out, append(cur, p))
+				cur = nil
+			} else {
+				cur = []pt{p}
+			}
+			k = (k + 1) % len(dash)
+			left = dash[k]
+		}
+		left -= l - t
+		if k%2 == 0 {
+			cur = append(cur, b)
+		}
+	}
+	if len(cur) > 1 {
+		out = append(out, cur)
+	}
+	return out
+}
+
+type anchor int
+
+const (
+	start anchor = iota
+	middle
+	end
+)
+
+// canvas is implemented by each output format.
+type canvas interface {
+	// polyline draws a line through pts.
+	polyline(pts []pt, s stroke)
+	// polygon fills the closed shape pts.
+	polygon(pts []pt, fill color.NRGBA)
+	// circle fills and strokes a circle.
+	circle(c pt, r float64, fill color.NRGBA, s stroke)
+	// text draws s with its baseline at p. If vertical, it reads from bottom to top.
+	text(p pt, s string, c color.NRGBA, a anchor, vertical bool)
+}
+
+const (
+	// font metrics are those of the basic font used for PNG.
+	charWidth  = 7
+	lineHeight = 13
+	pad        = 8
+	tickLength = 5
+)
+
+var (
+	// defaultColor is the Chart.js default color for lines and fills.
+	defaultColor = color.NRGBA{0, 0, 0, 26}
+	fontColor    = color.NRGBA{102, 102, 102, 255}
+	gridColor    = color.NRGBA{0, 0, 0, 26}
+	// zeroLineColor is the Chart.js default for the grid line at 0.
+	zeroLineColor = color.NRGBA{0, 0, 0, 64}
+	white         = color.NRGBA{255, 255, 255, 255}
+)
+
+func rgba(c *types.RGBA, def color.NRGBA) color.NRGBA {
+	if c == nil {
+		return def
+	}
+	return color.NRGBA{c.R, c.G, c.B, c.A}
+}
+
+func isTrue(b types.Bool) bool {
+	return b != nil && *b
+}
+
+func isFalse(b types.Bool) bool {
+	return b != nil && !*b
+}
+
+// capName returns the SVG name of a chartjs cap style.
+func capName(c int) string {
+	switch c {
+	case int(chartjs.CapButt):
+		return "butt"
+	case int(chartjs.CapSquare):
+		return "square"
+	}
+	return ""
+}
+
+// joinName returns the SVG name of a chartjs join style.
+func joinName(j int) string {
+	switch j {
+	case int(chartjs.JoinBevel):
+		return "bevel"
+	case int(chartjs.JoinMiter):
+		return "miter"
+	}
+	return ""
+}
+
+func textWidth(s string) float64 {
+	return float64(len([]rune(s)) * charWidth)
+}
+
+type scaleKind int
+
+const (
+	linearScale scaleKind = iota
+	logScale
+	categoryScale
+)
+
+type tick struct {
+	v     float64
+	label string
+}
+
+// scale maps data values to pixels along an axis.
+type scale struct {
+	axis       chartjs.Axis
+	horizontal bool
+	kind       scaleKind
+	labels     []string
+	min, max   float64
+	// lo and hi are the pixels of min and max.
+	lo, hi float64
+	ticks  []tick
+	// size is the space taken by the ticks and label perpendicular to the axis.
+	size float64
+}
+
+func (s *scale) pix(v float64) float64 {
+	switch s.kind {
+	case categoryScale:
+		n := math.Max(float64(len(s.labels)), 1)
+		return s.lo + (v+0.5)/n*(s.hi-s.lo)
+	case logScale:
+		if v <= 0 {
+			return math.NaN()
+		}
+		return s.lo + (math.Log10(v)-math.Log10(s.min))/(math.Log10(s.max)-math.Log10(s.min))*(s.hi-s.lo)
+	}
+	return s.lo + (v-s.min)/(s.max-s.min)*(s.hi-s.lo)
+}
+
+// band returns the width in pixels of a category.
+func (s *scale) band() float64 {
+	return math.Abs(s.hi-s.lo) / math.Max(float64(len(s.labels)), 1)
+}
+
+func (s *scale) display() bool {
+	return !isFalse(s.axis.Display)
+}
+
+// fit sets the range of the scale to include vals and chooses the ticks.
+func (s *scale) fit(vals []float64, zero bool) {
+	if s.kind == categoryScale {
+		s.min, s.max = 0, float64(len(s.labels)-1)
+		for i, l := range s.labels {
+			s.ticks = append(s.ticks, tick{float64(i), l})
+		}
+		return
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range vals {
+		if math.IsNaN(v) || math.IsInf(v, 0) || (s.kind == logScale && v <= 0) {
+			continue
+		}
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if t := s.axis.Tick; t != nil && isTrue(t.BeginAtZero) {
+		zero = true
+	}
+	if zero && s.kind == linearScale {
+		lo, hi = math.Min(lo, 0), math.Max(hi, 0)
+	}
+	if math.IsInf(lo, 1) {
+		lo, hi = 0, 1
+		if s.kind == logScale {
+			lo, hi = 1, 10
+		}
+	}
+	if s.kind == logScale {
+		s.min, s.max = math.Pow(10, math.Floor(math.Log10(lo))), math.Pow(10, math.Ceil(math.Log10(hi)))
+		if s.max == s.min {
+			s.max *= 10
+		}
+		for e := math.Log10(s.min); e <= math.Log10(s.max)+1e-9; e++ {
+			v := math.Pow(10, e)
+			s.ticks = append(s.ticks, tick{v, strconv.FormatFloat(v, 'g', -1, 64)})
+		}
+		s.axisTicks()
+		return
+	}
+	if hi == lo {
+		lo, hi = lo-1, hi+1
+	}
+	step := nice.Step((hi - lo) / 6)
+	s.min, s.max = math.Floor(lo/step)*step, math.Ceil(hi/step)*step
+	// explicit limits are used as given.
+	if t := s.axis.Tick; t != nil {
+		if t.Min != 0 {
+			s.min = t.Min
+		}
+		if t.Max != 0 {
+			s.max = t.Max
+		}
+	}
+	for v := math.Ceil(s.min/step) * step; v <= s.max+step*1e-9; v += step {
+		if math.Abs(v) < step*1e-9 {
+			v = 0
+		}
+		s.ticks = append(s.ticks, tick{v, strconv.FormatFloat(v, 'g', 6, 64)})
+	}
+	s.axisTicks()
+}
+
+// axisTicks replaces the ticks of a Log axis with LogOptions, a Symlog axis or an axis with
+// Breaks with those of Axis.Ticks within its range.
+func (s *scale) axisTicks() {
+	vals, labels := s.axis.Ticks(s.min, s.max)
+	if vals == nil {
+		return
+	}
+	eps := (s.max - s.min) * 1e-9
+	s.ticks = s.ticks[:0]
+	for i, v := range vals {
+		if v >= s.min-eps && v <= s.max+eps {
+			s.ticks = append(s.ticks, tick{v, labels[i]})
+		}
+	}
+}
+
+// series holds the values of a dataset in data coordinates.
+type series struct {
+	d          chartjs.Dataset
+	typ        string
+	xs, ys, rs []float64
+	// base holds the bottom of stacked values.
+	base []float64
+	// lows and highs are the ends of the error bars.
+	lows, highs []float64
+	x, y        *scale
+}
+
+// chartTypes are the types of charts that may be rendered.
+var chartTypes = map[string]bool{"line": true, "bar": true, "bubble": true}
+
+func typeName(t interface{ MarshalJSON() ([]byte, error) }) string {
+	b, _ := t.MarshalJSON()
+	s, _ := strconv.Unquote(string(b))
+	return s
+}
+
+type chart struct {
+	c            chartjs.Chart
+	width        float64
+	height       float64
+	xaxes, yaxes []*scale
+	series       []*series
+	area         struct{ left, top, right, bottom float64 }
+}
+
+func newChart(c chartjs.Chart, width, height int) (*chart, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("render: width and height must be positive")
+	}
+	ch := &chart{c: c, width: float64(width), height: float64(height)}
+	ctype := typeName(c.ResolvedType())
+	labels := c.Data.Labels
+
+	for _, a := range c.Options.Scales.XAxes {
+		ch.xaxes = append(ch.xaxes, newScale(a, true, labels, c.Data.XLabels))
+	}
+	for _, a := range c.Options.Scales.YAxes {
+		ch.yaxes = append(ch.yaxes, newScale(a, false, labels, c.Data.YLabels))
+	}
+	if len(ch.xaxes) == 0 {
+		a := chartjs.Axis{Type: chartjs.Linear, Position: chartjs.Bottom}
+		if ctype == "bar" || len(labels) > 0 {
+			a.Type = chartjs.Category
+		}
+		ch.xaxes = append(ch.xaxes, newScale(a, true, labels, c.Data.XLabels))
+	}
+	if len(ch.yaxes) == 0 {
+		ch.yaxes = append(ch.yaxes, newScale(chartjs.Axis{Type: chartjs.Linear, Position: chartjs.Left}, false, labels, c.Data.YLabels))
+	}
+
+	for i, d := range c.Data.Datasets {
+		typ := ctype
+		if d.Type != 0 {
+			typ = typeName(d.Type)
+		}
+		if !chartTypes[typ] || d.Data == nil {
+			return nil, fmt.Errorf("render: dataset %d: only line, bar and bubble datasets with Data are supported", i)
+		}
+		s := &series{d: d, typ: typ, x: find(ch.xaxes, d.XAxisID), y: find(ch.yaxes, d.YAxisID)}
+		if s.x == nil || s.y == nil {
+			return nil, fmt.Errorf("render: dataset %d: axis not found", i)
+		}
+		xs, ys, rs := d.Data.Xs(), d.Data.Ys(), d.Data.Rs()
+		if len(ys) == 0 {
+			// only a single set of values which are placed by index.
+			xs, ys = nil, xs
+		}
+		// values are placed on Log and Symlog axes as in the JSON.
+		xs, ys = transform(s.x.axis, xs), transform(s.y.axis, ys)
+		if len(xs) == 0 || s.x.kind == categoryScale {
+			xs = make([]float64, len(ys))
+			for j := range xs {
+				xs[j] = float64(j)
+			}
+		}
+		if len(xs) != len(ys) || (len(rs) > 0 && len(rs) != len(ys)) {
+			return nil, fmt.Errorf("render: dataset %d: all axes must be of the same length", i)
+		}
+		s.xs, s.ys, s.rs = xs, ys, rs
+		if ev, ok := d.Data.(chartjs.ErrorValues); ok {
+			s.lows, s.highs = transform(s.y.axis, ev.YLow()), transform(s.y.axis, ev.YHigh())
+			if len(s.lows) != len(ys) || len(s.highs) != len(ys) {
+				return nil, fmt.Errorf("render: dataset %d: error values must match the length of the data", i)
+			}
+		}
+		ch.series = append(ch.series, s)
+	}
+	ch.stack()
+	for _, s := range ch.xaxes {
+		var vals []float64
+		for _, sr := range ch.series {
+			if sr.x == s {
+				vals = append(vals, sr.xs...)
+			}
+		}
+		s.fit(vals, false)
+	}
+	for _, s := range ch.yaxes {
+		var vals []float64
+		zero := false
+		for _, sr := range ch.series {
+			if sr.y == s {
+				vals = append(vals, sr.ys...)
+				vals = append(vals, sr.base...)
+				vals = append(vals, sr.lows...)
+				vals = append(vals, sr.highs...)
+				zero = zero || sr.typ == "bar"
+			}
+		}
+		s.fit(vals, zero)
+	}
+	return ch, nil
+}
+
+func newScale(a chartjs.Axis, horizontal bool, labels, axisLabels []string) *scale {
+	s := &scale{axis: a, horizontal: horizontal}
+	switch a.Type {
+	case chartjs.Category:
+		s.kind = categoryScale
+		s.labels = labels
+		if len(axisLabels) > 0 {
+			s.labels = axisLabels
+		}
+	case chartjs.Log:
+		s.kind = logScale
+	}
+	return s
+}
+
+// transform returns the values placed on the axis by Axis.Transform.
+func transform(a chartjs.Axis, vals []float64) []float64 {
+	if a.Type != chartjs.Log && a.Type != chartjs.Symlog && len(a.Breaks) == 0 {
+		return vals
+	}
+	out := make([]float64, len(vals))
+	for i, v := range vals {
+		out[i] = a.Transform(v)
+	}
+	return out
+}
+
+// find returns the scale with the id or the first if id is empty.
+func find(scales []*scale, id string) *scale {
+	for _, s := range scales {
+		if s.axis.ID == id || id == "" {
+			return s
+		}
+	}
+	return nil
+}
+
+// stack accumulates the values of datasets on stacked axes.
+func (ch *chart) stack() {
+	// bars are stacked within their Stack group.
+	type key struct {
+		y     *scale
+		stack string
+	}
+	pos, neg := make(map[key][]float64), make(map[key][]float64)
+	for _, s := range ch.series {
+		if !isTrue(s.y.axis.Stacked) {
+			continue
+		}
+		k := key{y: s.y}
+		if s.typ == "bar" {
+			k.stack = "bar:" + s.d.Stack
+		}
+		p, n := pos[k], neg[k]
+		for len(p) < len(s.ys) {
+			p, n = append(p, 0), append(n, 0)
+		}
+		s.base = make([]float64, len(s.ys))
+		ys := make([]float64, len(s.ys))
+		for i, y := range s.ys {
+			if math.IsNaN(y) {
+				ys[i], s.base[i] = y, math.NaN()
+				continue
+			}
+			acc := p
+			if y < 0 {
+				acc = n
+			}
+			s.base[i] = acc[i]
+			acc[i] += y
+			ys[i] = acc[i]
+		}
+		s.ys = ys
+		pos[k], neg[k] = p, n
+	}
+}
+
+// side returns the position of the scale with the defaults of Chart.js.
+func (s *scale) side() int {
+	switch {
+	case s.axis.Position != 0:
+		return int(s.axis.Position)
+	case s.horizontal:
+		return int(chartjs.Bottom)
+	}
+	return int(chartjs.Left)
+}
+
+// offset returns the distance of the scale from the chart area as axes on the same side are
+// stacked outwards.
+func (ch *chart) offset(s *scale) float64 {
+	var o float64
+	for _, other := range append(append([]*scale{}, ch.xaxes...), ch.yaxes...) {
+		if other == s {
+			break
+		}
+		if other.display() && other.horizontal == s.horizontal && other.side() == s.side() {
+			o += other.size
+		}
+	}
+	return o
+}
+
+// layout places the title, legend and axes and sets the pixels of each scale.
+func (ch *chart) layout() {
+	top := float64(pad)
+	if t := ch.c.Options.Title; t != nil && isTrue(t.Display) && t.Text != "" {
+		top += lineHeight + pad
+	}
+	if rows := ch.legendRows(); len(rows) > 0 {
+		top += float64(len(rows))*(lineHeight+4) + pad
+	}
+	left, right, bottom := float64(pad), ch.width-pad, ch.height-pad
+	for _, s := range append(append([]*scale{}, ch.xaxes...), ch.yaxes...) {
+		if !s.display() {
+			continue
+		}
+		if s.horizontal {
+			s.size = tickLength + lineHeight + 4
+		} else {
+			var w float64
+			for _, t := range s.ticks {
+				w = math.Max(w, textWidth(t.label))
+			}
+			s.size = tickLength + w + 4
+		}
+		if l := s.axis.ScaleLabel; l != nil && isTrue(l.Display) && l.LabelString != "" {
+			s.size += lineHeight + 4
+		}
+		switch s.side() {
+		case int(chartjs.Top):
+			top += s.size
+		case int(chartjs.Right):
+			right -= s.size
+		case int(chartjs.Bottom):
+			bottom -= s.size
+		default:
+			left += s.size
+		}
+	}
+	ch.area.left, ch.area.top, ch.area.right, ch.area.bottom = left, top, right, bottom
+	for _, s := range ch.xaxes {
+		s.lo, s.hi = left, right
+	}
+	for _, s := range ch.yaxes {
+		s.lo, s.hi = bottom, top
+	}
+}
+
+type legendItem struct {
+	s     *series
+	label string
+}
+
+// legendRows wraps the legend items to the width of the chart.
+func (ch *chart) legendRows() [][]legendItem {
+	if l := ch.c.Options.Legend; l != nil && isFalse(l.Display) {
+		return nil
+	}
+	var rows [][]legendItem
+	var row []legendItem
+	var w float64
+	for _, s := range ch.series {
+		if s.d.Label == "" {
+			continue
+		}
+		iw := legendWidth(s.d.Label)
+		if len(row) > 0 && w+iw > ch.width-2*pad {
+			rows, row, w = append(rows, row), nil, 0
+		}
+		row = append(row, legendItem{s, s.d.Label})
+		w += iw
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func legendWidth(label string) float64 {
+	return 40 + textWidth(label) + 10
+}
+
+// draw renders the chart to c.
+func (ch *chart) draw(c canvas) {
+	c.polygon([]pt{{0, 0}, {ch.width, 0}, {ch.width, ch.height}, {0, ch.height}}, white)
+	ch.layout()
+	y := float64(pad)
+	if t := ch.c.Options.Title; t != nil && isTrue(t.Display) && t.Text != "" {
+		y += lineHeight
+		c.text(pt{ch.width / 2, y}, t.Text, fontColor, middle, false)
+		y += pad
+	}
+	for _, row := range ch.legendRows() {
+		var w float64
+		for _, it := range row {
+			w += legendWidth(it.label)
+		}
+		x := (ch.width - w) / 2
+		y += lineHeight + 4
+		for _, it := range row {
+			box := []pt{{x + 10, y - 10}, {x + 10 + 30, y - 10}, {x + 10 + 30, y}, {x + 10, y}}
+			c.polygon(box, rgba(it.s.d.BackgroundColor, defaultColor))
+			if bw := it.s.d.BorderWidth; bw > 0 {
+				c.polyline(append(box, box[0]), stroke{color: rgba(it.s.d.BorderColor, defaultColor), width: bw})
+			}
+			c.text(pt{x + 45, y - 1}, it.label, fontColor, start, false)
+			x += legendWidth(it.label)
+		}
+	}
+
+	for _, s := range ch.yaxes {
+		ch.drawAxis(c, s)
+	}
+	for _, s := range ch.xaxes {
+		ch.drawAxis(c, s)
+	}
+	// each bar has its own slot in a category unless it is stacked with others.
+	slots := make(map[string]int)
+	slot := make(map[*series]int)
+	for i, s := range ch.series {
+		if s.typ != "bar" {
+			continue
+		}
+		k := strconv.Itoa(i)
+		if s.base != nil {
+			k = "stack:" + s.d.Stack
+		}
+		if _, ok := slots[k]; !ok {
+			slots[k] = len(slots)
+		}
+		slot[s] = slots[k]
+	}
+	nbars := len(slots)
+	// datasets with a lower Order are drawn last, on top.
+	order := append([]*series{}, ch.series...)
+	sort.SliceStable(order, func(i, j int) bool { return order[i].d.Order > order[j].d.Order })
+	for _, s := range order {
+		i := slot[s]
+		if s.lows != nil && s.d.ErrorMode == chartjs.ErrorBand {
+			ch.drawBand(c, s)
+		}
+		switch s.typ {
+		case "bar":
+			ch.drawBars(c, s, i, nbars)
+		case "bubble":
+			ch.drawPoints(c, s)
+		default:
+			ch.drawLine(c, s)
+			ch.drawPoints(c, s)
+		}
+		ch.drawErrors(c, s, i, nbars)
+	}
+	for _, s := range append(append([]*scale{}, ch.xaxes...), ch.yaxes...) {
+		ch.drawBreaks(c, s)
+	}
+}
+
+// drawBreaks draws a zig-zag across the chart at each of the Breaks of the axis.
+func (ch *chart) drawBreaks(c canvas, s *scale) {
+	a := ch.area
+	for _, b := range s.axis.Breaks {
+		p := s.pix(s.axis.Transform(b.From))
+		lo, hi := a.left, a.right
+		if s.horizontal {
+			lo, hi = a.top, a.bottom
+		}
+		if math.IsNaN(p) || p < math.Min(s.lo, s.hi) || p > math.Max(s.lo, s.hi) {
+			continue
+		}
+		zig := func(o float64) []pt {
+			var pts []pt
+			for q, i := lo, 0; q < hi+4; q, i = q+4, i+1 {
+				d := p + o - 2
+				if i%2 == 1 {
+					d = p + o + 2
+				}
+				if s.horizontal {
+					pts = append(pts, pt{d, math.Min(q, hi)})
+				} else {
+					pts = append(pts, pt{math.Min(q, hi), d})
+				}
+			}
+			return pts
+		}
+		one, two := zig(-3), zig(3)
+		poly := append([]pt{}, one...)
+		for i := len(two) - 1; i >= 0; i-- {
+			poly = append(poly, two[i])
+		}
+		c.polygon(poly, color.NRGBA{255, 255, 255, 255})
+		st := stroke{color: color.NRGBA{0, 0, 0, 128}, width: 1}
+		c.polyline(one, st)
+		c.polyline(two, st)
+	}
+}
+
+func (ch *chart) drawAxis(c canvas, s *scale) {
+	a := ch.area
+	g := s.axis.GridLines
+	if g == nil {
+		g = &chartjs.GridLineOptions{}
+	}
+	// the border and tick marks have the color and width of the grid lines but aren't dashed.
+	border := stroke{color: rgba(g.Color, gridColor), width: 1}
+	if g.LineWidth > 0 {
+		border.width = g.LineWidth
+	}
+	grid := border
+	grid.dash, grid.dashOffset = g.BorderDash, g.BorderDashOffset
+	zero := stroke{color: rgba(g.ZeroLineColor, zeroLineColor), width: 1, dash: g.ZeroLineBorderDash, dashOffset: g.ZeroLineBorderDashOffset}
+	if g.ZeroLineWidth > 0 {
+		zero.width = g.ZeroLineWidth
+	}
+	if !isFalse(g.Display) && !isFalse(g.DrawOnChartArea) {
+		for _, t := range s.ticks {
+			p, st := s.pix(t.v), grid
+			if t.v == 0 && s.kind == linearScale && s.min < 0 {
+				st = zero
+			}
+			if s.horizontal {
+				c.polyline([]pt{{p, a.top}, {p, a.bottom}}, st)
+			} else {
+				c.polyline([]pt{{a.left, p}, {a.right, p}}, st)
+			}
+		}
+	}
+	ticks := border
+	if isFalse(g.DrawTicks) {
+		ticks.width = 0
+	}
+	if isFalse(g.DrawBorder) {
+		border.width = 0
+	}
+	if !s.display() {
+		return
+	}
+	label := ""
+	if l := s.axis.ScaleLabel; l != nil && isTrue(l.Display) {
+		label = l.LabelString
+	}
+	off := ch.offset(s)
+	if s.horizontal {
+		y, dir := a.bottom+off, 1.0
+		if s.side() == int(chartjs.Top) {
+			y, dir = a.top-off, -1
+		}
+		c.polyline([]pt{{a.left, y}, {a.right, y}}, border)
+		for _, t := range s.ticks {
+			p := s.pix(t.v)
+			c.polyline([]pt{{p, y}, {p, y + dir*tickLength}}, ticks)
+			ty := y + tickLength + lineHeight
+			if dir < 0 {
+				ty = y - tickLength - 4
+			}
+			c.text(pt{p, ty}, t.label, fontColor, middle, false)
+		}
+		if label != "" {
+			ly := y + s.size - 4
+			if dir < 0 {
+				ly = y - s.size + lineHeight
+			}
+			c.text(pt{(a.left + a.right) / 2, ly}, label, fontColor, middle, false)
+		}
+		return
+	}
+	x, dir := a.left-off, -1.0
+	right := s.side() == int(chartjs.Right)
+	if right {
+		x, dir = a.right+off, 1
+	}
+	c.polyline([]pt{{x, a.top}, {x, a.bottom}}, border)
+	for _, t := range s.ticks {
+		p := s.pix(t.v)
+		c.polyline([]pt{{x, p}, {x + dir*tickLength, p}}, ticks)
+		if right {
+			c.text(pt{x + tickLength + 2, p + 4}, t.label, fontColor, start, false)
+		} else {
+			c.text(pt{x - tickLength - 2, p + 4}, t.label, fontColor, end, false)
+		}
+	}
+	if label != "" {
+		// vertical text is drawn with its baseline towards the chart area.
+		lx := x - s.size + lineHeight
+		if right {
+			lx = x + s.size - 4
+		}
+		c.text(pt{lx, (a.top + a.bottom) / 2}, label, fontColor, middle, true)
+	}
+}
+
+// barOptions returns the BarOptions of the dataset of s, with those that are unset taken from
+// its x axis or else from the defaults of Chart.js.
+func barOptions(s *series) chartjs.BarOptions {
+	var o chartjs.BarOptions
+	for _, b := range []*chartjs.BarOptions{s.d.BarOptions, s.x.axis.Bar,
+		{CategoryPercentage: 0.8, BarPercentage: 0.9}} {
+		if b == nil {
+			continue
+		}
+		for _, f := range []struct{ v, b *float64 }{
+			{&o.BarPercentage, &b.BarPercentage},
+			{&o.CategoryPercentage, &b.CategoryPercentage},
+			{&o.BarThickness, &b.BarThickness},
+			{&o.MaxBarThickness, &b.MaxBarThickness},
+			{&o.MinBarLength, &b.MinBarLength},
+		} {
+			if *f.v <= 0 {
+				*f.v = *f.b
+			}
+		}
+	}
+	return o
+}
+
+// barGeometry returns the center and width in pixels of the bar at index i.
+func (ch *chart) barGeometry(s *series, i, ibar, nbars int) (float64, float64) {
+	x := s.x.pix(s.xs[i])
+	o := barOptions(s)
+	n := float64(nbars)
+	if n < 1 {
+		n = 1
+	}
+
+	var size, ratio float64
+	if o.BarThickness > 0 {
+		size, ratio = o.BarThickness*n, 1
+	} else {
+		size = s.x.band()
+		if s.x.kind != categoryScale {
+			// use the smallest distance between bars.
+			size = math.Abs(s.x.hi - s.x.lo)
+			for j := 1; j < len(s.xs); j++ {
+				size = math.Min(size, math.Abs(s.x.pix(s.xs[j])-s.x.pix(s.xs[j-1])))
+			}
+		}
+		size, ratio = size*o.CategoryPercentage, o.BarPercentage
+	}
+	chunk := size / n
+	x += (float64(ibar) - (n-1)/2) * chunk
+	w := chunk * ratio
+	if o.MaxBarThickness > 0 {
+		w = math.Min(w, o.MaxBarThickness)
+	}
+	return x, w
+}
+
+// colorAt returns the color at index i of colors, or def if there are too few.
+func colorAt(colors []types.RGBA, i int, def color.NRGBA) color.NRGBA {
+	if i < len(colors) {
+		return rgba(&colors[i], def)
+	}
+	return def
+}
+
+func (ch *chart) drawBars(c canvas, s *series, ibar, nbars int) {
+	fill := rgba(s.d.BackgroundColor, defaultColor)
+	border := stroke{color: rgba(s.d.BorderColor, defaultColor), width: s.d.BorderWidth}
+	min := barOptions(s).MinBarLength
+	for i, v := range s.ys {
+		if math.IsNaN(v) {
+			continue
+		}
+		x, w := ch.barGeometry(s, i, ibar, nbars)
+		b := 0.0
+		if s.base != nil {
+			b = s.base[i]
+		}
+		y0, y1 := s.y.pix(b), s.y.pix(v)
+		if math.IsNaN(y0) {
+			y0 = s.y.lo
+		}
+		if math.IsNaN(y1) {
+			continue
+		}
+		if math.Abs(y1-y0) < min {
+			// the bar grows away from its base in the direction of the value.
+			dir := -1.0
+			if y1 > y0 || (y1 == y0 && v < b) {
+				dir = 1
+			}
+			y1 = y0 + dir*min
+		}
+		// the corners are in the order base left, tip left, tip right and base right.
+		r := []pt{{x - w/2, y0}, {x - w/2, y1}, {x + w/2, y1}, {x + w/2, y0}}
+		c.polygon(r, colorAt(s.d.BackgroundColors, i, fill))
+		if border.width > 0 {
+			st := border
+			st.color = colorAt(s.d.BorderColors, i, border.color)
+			c.polyline(barBorder(r, int(s.d.BorderSkipped)), st)
+		}
+	}
+}
+
+// barBorder returns the path around the corners of a bar that leaves out the skipped edge.
+// Chart.js skips the border at the base of the bar by default.
+func barBorder(r []pt, skipped int) []pt {
+	// the edge ending at each corner, which is where the path starts when it is skipped.
+	start := 0
+	switch skipped {
+	case int(chartjs.SkipNone):
+		return append(r, r[0])
+	case int(chartjs.SkipLeft):
+		start = 1
+	case int(chartjs.SkipTop):
+		start = 2
+	case int(chartjs.SkipRight):
+		start = 3
+	}
+	return append(append([]pt{}, r[start:]...), r[:start]...)
+}
+
+// pixels returns the points of the series in pixels. Runs of points are split at missing values
+// unless SpanGaps is set.
+func (ch *chart) pixels(s *series) [][]pt {
+	var runs [][]pt
+	var run []pt
+	for i := range s.ys {
+		p := pt{s.x.pix(s.xs[i]), s.y.pix(s.ys[i])}
+		if math.IsNaN(p.x) || math.IsNaN(p.y) {
+			if !isTrue(s.d.SpanGaps) && len(run) > 0 {
+				runs, run = append(runs, run), nil
+			}
+			continue
+		}
+		run = append(run, p)
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// stepped returns the path through pts that steps before each point.
+func stepped(pts []pt) []pt {
+	out := make([]pt, 0, 2*len(pts))
+	for i, p := range pts {
+		if i > 0 {
+			out = append(out, pt{p.x, pts[i-1].y})
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// index returns the index of the dataset of s.
+func (ch *chart) index(s *series) int {
+	for i, o := range ch.series {
+		if o == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ch *chart) drawLine(c canvas, s *series) {
+	if isFalse(s.d.ShowLine) {
+		return
+	}
+	width := s.d.BorderWidth
+	if width <= 0 {
+		// a canvas ignores a width of 0 so the default is used.
+		width = 1
+	}
+	for _, run := range ch.pixels(s) {
+		if isTrue(s.d.SteppedLine) {
+			run = stepped(run)
+		}
+		// lines are filled to the origin (or the previous stack) unless Fill is set otherwise.
+		if s.d.Fill != chartjs.NoFill && len(run) > 1 {
+			poly := append([]pt{}, run...)
+			y0 := s.y.pix(0)
+			if math.IsNaN(y0) || s.y.min > 0 {
+				y0 = s.y.lo
+			} else if s.y.max < 0 {
+				y0 = s.y.hi
+			}
+			t, toDataset := s.d.Fill.Dataset(ch.index(s))
+			switch {
+			case toDataset:
+				if t < 0 || t >= len(ch.series) {
+					break
+				}
+				// fill between the lines.
+				var other []pt
+				for _, r := range ch.pixels(ch.series[t]) {
+					if isTrue(ch.series[t].d.SteppedLine) {
+						r = stepped(r)
+					}
+					other = append(other, r...)
+				}
+				for i := len(other) - 1; i >= 0; i-- {
+					poly = append(poly, other[i])
+				}
+			case s.d.Fill == chartjs.FillStart, s.d.Fill == chartjs.FillEnd:
+				y := s.y.lo
+				if s.d.Fill == chartjs.FillEnd {
+					y = s.y.hi
+				}
+				poly = append(poly, pt{run[len(run)-1].x, y}, pt{run[0].x, y})
+			case s.d.Fill == chartjs.FillOrigin:
+				poly = append(poly, pt{run[len(run)-1].x, y0}, pt{run[0].x, y0})
+			case s.base != nil:
+				var base []pt
+				for i := range s.ys {
+					if p := (pt{s.x.pix(s.xs[i]), s.y.pix(s.base[i])}); !math.IsNaN(p.x) && !math.IsNaN(p.y) {
+						base = append(base, p)
+					}
+				}
+				for i := len(base) - 1; i >= 0; i-- {
+					poly = append(poly, base[i])
+				}
+			default:
+				poly = append(poly, pt{run[len(run)-1].x, y0}, pt{run[0].x, y0})
+			}
+			c.polygon(poly, rgba(s.d.BackgroundColor, defaultColor))
+		}
+		c.polyline(run, stroke{color: rgba(s.d.BorderColor, defaultColor), width: width, dash: s.d.BorderDash,
+			dashOffset: s.d.BorderDashOffset, cap: capName(int(s.d.BorderCapStyle)), join: joinName(int(s.d.BorderJoinStyle))})
+	}
+}
+
+func (ch *chart) drawBand(c canvas, s *series) {
+	var lo, hi []pt
+	for i := range s.xs {
+		x := s.x.pix(s.xs[i])
+		l, h := s.y.pix(s.lows[i]), s.y.pix(s.highs[i])
+		if math.IsNaN(x) || math.IsNaN(l) || math.IsNaN(h) {
+			continue
+		}
+		lo, hi = append(lo, pt{x, l}), append(hi, pt{x, h})
+	}
+	for i := len(lo) - 1; i >= 0; i-- {
+		hi = append(hi, lo[i])
+	}
+	c.polygon(hi, rgba(s.d.BackgroundColor, defaultColor))
+}
+
+func (ch *chart) drawPoints(c canvas, s *series) {
+	fill := rgba(s.d.PointBackgroundColor, rgba(s.d.BackgroundColor, defaultColor))
+	border := stroke{color: rgba(s.d.PointBorderColor, rgba(s.d.BorderColor, defaultColor)), width: s.d.PointBorderWidth}
+	if s.typ == "bubble" {
+		fill = rgba(s.d.BackgroundColor, defaultColor)
+		border = stroke{color: rgba(s.d.BorderColor, defaultColor), width: s.d.BorderWidth}
+	}
+	// per-point colors are used unless the point colors are set.
+	var fills, borders []types.RGBA
+	if s.typ == "bubble" || s.d.PointBackgroundColor == nil {
+		fills = s.d.BackgroundColors
+	}
+	if s.typ == "bubble" || s.d.PointBorderColor == nil {
+		borders = s.d.BorderColors
+	}
+	for i := range s.ys {
+		r := s.d.PointRadius
+		if s.typ == "bubble" && len(s.rs) > 0 {
+			r = s.rs[i]
+		}
+		p := pt{s.x.pix(s.xs[i]), s.y.pix(s.ys[i])}
+		if r <= 0 || math.IsNaN(p.x) || math.IsNaN(p.y) {
+			continue
+		}
+		st := border
+		st.color = colorAt(borders, i, border.color)
+		marker(c, int(s.d.PointStyle), p, r, colorAt(fills, i, fill), st)
+	}
+}
+
+// marker draws a point with the shape of the chartjs point style.
+func marker(c canvas, style int, p pt, r float64, fill color.NRGBA, s stroke) {
+	poly := func(pts []pt) {
+		c.polygon(pts, fill)
+		if s.width > 0 {
+			c.polyline(append(pts, pts[0]), s)
+		}
+	}
+	d := r / math.Sqrt2
+	switch style {
+	case chartjs.Triangle:
+		h := r * 3 / math.Sqrt(3)
+		poly([]pt{{p.x - r, p.y + h/3}, {p.x + r, p.y + h/3}, {p.x, p.y - 2*h/3}})
+	case chartjs.Rect:
+		poly([]pt{{p.x - d, p.y - d}, {p.x + d, p.y - d}, {p.x + d, p.y + d}, {p.x - d, p.y + d}})
+	case chartjs.RectRot:
+		poly([]pt{{p.x - r, p.y}, {p.x, p.y - r}, {p.x + r, p.y}, {p.x, p.y + r}})
+	case chartjs.Cross:
+		c.polyline([]pt{{p.x, p.y - r}, {p.x, p.y + r}}, s)
+		c.polyline([]pt{{p.x - r, p.y}, {p.x + r, p.y}}, s)
+	case chartjs.CrossRot:
+		c.polyline([]pt{{p.x - d, p.y - d}, {p.x + d, p.y + d}}, s)
+		c.polyline([]pt{{p.x - d, p.y + d}, {p.x + d, p.y - d}}, s)
+	case chartjs.Star:
+		c.polyline([]pt{{p.x, p.y - r}, {p.x, p.y + r}}, s)
+		c.polyline([]pt{{p.x - r, p.y}, {p.x + r, p.y}}, s)
+		c.polyline([]pt{{p.x - d, p.y - d}, {p.x + d, p.y + d}}, s)
+		c.polyline([]pt{{p.x - d, p.y + d}, {p.x + d, p.y - d}}, s)
+	case chartjs.LinePoint:
+		c.polyline([]pt{{p.x - r, p.y}, {p.x + r, p.y}}, s)
+	case chartjs.Dash:
+		c.polyline([]pt{{p.x, p.y}, {p.x + r, p.y}}, s)
+	default:
+		c.circle(p, r, fill, s)
+	}
+}
+
+func (ch *chart) drawErrors(c canvas, s *series, ibar, nbars int) {
+	if s.lows == nil || s.d.ErrorMode != chartjs.ErrorBars {
+		return
+	}
+	st := stroke{color: rgba(s.d.BorderColor, color.NRGBA{0, 0, 0, 204}), width: 1}
+	for i := range s.ys {
+		x := s.x.pix(s.xs[i])
+		if s.typ == "bar" {
+			x, _ = ch.barGeometry(s, i, ibar, nbars)
+		}
+		lo, hi := s.y.pix(s.lows[i]), s.y.pix(s.highs[i])
+		if math.IsNaN(x) || math.IsNaN(lo) || math.IsNaN(hi) {
+			continue
+		}
+		c.polyline([]pt{{x, lo}, {x, hi}}, st)
+		c.polyline([]pt{{x - 4, lo}, {x + 4, lo}}, st)
+		c.polyline([]pt{{x - 4, hi}, {x + 4, hi}}, st)
+	}
+}
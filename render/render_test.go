@@ -0,0 +1,270 @@
+package render
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	chartjs "github.com/brentp/go-chartjs"
+	"github.com/brentp/go-chartjs/types"
+)
+
+func lineChart() chartjs.Chart {
+	var xys1, xys2 chartjs.XY
+	for i := float64(0); i < 9; i += 0.1 {
+		xys1.X = append(xys1.X, i)
+		xys2.X = append(xys2.X, i)
+		xys1.Y = append(xys1.Y, math.Sin(i))
+		xys2.Y = append(xys2.Y, 3*math.Cos(2*i))
+	}
+	d1 := chartjs.Dataset{Data: xys1, BorderColor: &types.RGBA{250, 141, 98, 220}, Label: "sin(x)", Fill: chartjs.FillBool(chartjs.False),
+		PointRadius: 3, PointBorderWidth: 1, BackgroundColor: &types.RGBA{102, 194, 165, 220}}
+	d2 := chartjs.Dataset{Data: xys2, BorderWidth: 4, BorderColor: &types.RGBA{230, 138, 195, 220}, Label: "3*cos(2*x)",
+		BackgroundColor: &types.RGBA{230, 138, 195, 60}, SteppedLine: chartjs.True}
+
+	chart := chartjs.Chart{Label: "test-chart"}
+	chart.Options.Title = &chartjs.Title{Display: chartjs.True, Text: "Trig"}
+	chart.AddXAxis(chartjs.Axis{Type: chartjs.Linear, Position: chartjs.Bottom, ScaleLabel: &chartjs.ScaleLabel{LabelString: "X", Display: chartjs.True}})
+	d1.YAxisID, _ = chart.AddYAxis(chartjs.Axis{Type: chartjs.Linear, Position: chartjs.Left,
+		ScaleLabel: &chartjs.ScaleLabel{LabelString: "sin(x)", Display: chartjs.True}})
+	d2.YAxisID, _ = chart.AddYAxis(chartjs.Axis{Type: chartjs.Linear, Position: chartjs.Right,
+		ScaleLabel: &chartjs.ScaleLabel{LabelString: "3*cos(2*x)", Display: chartjs.True}})
+	chart.AddDataset(d1)
+	chart.AddDataset(d2)
+	return chart
+}
+
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SVG(&buf, lineChart(), 600, 400); err != nil {
+		t.Fatalf("error rendering svg: %+v", err)
+	}
+	s := buf.String()
+	for _, want := range []string{`<svg xmlns="http://www.w3.org/2000/svg" width="600"`, ">Trig</text>", ">sin(x)</text>",
+		`stroke="rgba(230,138,195,0.863)" stroke-width="4"`, `transform="rotate(-90`} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %q in svg", want)
+		}
+	}
+}
+
+func TestPNG(t *testing.T) {
+	bar := chartjs.Chart{Type: chartjs.Bar}
+	bar.Data.Labels = []string{"a", "b", "c"}
+	bar.AddDataset(chartjs.Dataset{Data: chartjs.Ys{3, -1, 2}, Label: "v",
+		BackgroundColor: &types.RGBA{0, 0, 255, 255}, BorderWidth: 1})
+	bubble := chartjs.Chart{Type: chartjs.Bubble}
+	bubble.AddDataset(chartjs.Dataset{Data: chartjs.XYR{X: []float64{1, 2}, Y: []float64{1, 2}, R: []float64{5, 10}},
+		BackgroundColor: &types.RGBA{255, 0, 0, 128}, PointStyle: chartjs.Rect})
+
+	for _, c := range []chartjs.Chart{lineChart(), bar, bubble} {
+		var buf bytes.Buffer
+		if err := PNG(&buf, c, 400, 300); err != nil {
+			t.Fatalf("error rendering png: %+v", err)
+		}
+		img, err := png.Decode(&buf)
+		if err != nil {
+			t.Fatalf("error decoding png: %+v", err)
+		}
+		if b := img.Bounds(); b.Dx() != 400 || b.Dy() != 300 {
+			t.Fatalf("unexpected size: %v", b)
+		}
+	}
+
+	img, _ := Image(bar, 400, 300)
+	var blue int
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if c := img.RGBAAt(x, y); c.B == 255 && c.R == 0 {
+				blue++
+			}
+		}
+	}
+	if blue < 1000 {
+		t.Fatalf("expected the bars to be drawn, got %d blue pixels", blue)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	c := chartjs.Chart{Type: chartjs.BoxPlot}
+	c.AddDataset(chartjs.Dataset{Samples: chartjs.RawSamples{{1, 2, 3}}})
+	if err := SVG(&bytes.Buffer{}, c, 100, 100); err == nil {
+		t.Fatalf("expected error rendering a box plot")
+	}
+}
+
+func TestOrderAndStacks(t *testing.T) {
+	red, blue, green := &types.RGBA{255, 0, 0, 255}, &types.RGBA{0, 0, 255, 255}, &types.RGBA{0, 255, 0, 255}
+	c := chartjs.Chart{Data: chartjs.Data{Labels: []string{"a", "b"}}}
+	c.AddDataset(chartjs.Dataset{Type: chartjs.Bar, Data: chartjs.Ys{1, 2}, BackgroundColor: red, Stack: "s"})
+	c.AddDataset(chartjs.Dataset{Type: chartjs.Bar, Data: chartjs.Ys{3, 4}, BackgroundColor: blue, Stack: "s", Order: 1})
+	c.AddDataset(chartjs.Dataset{Type: chartjs.Bar, Data: chartjs.Ys{5, 6}, BackgroundColor: green, Stack: "t"})
+	c.AddYAxis(chartjs.Axis{Type: chartjs.Linear, Stacked: chartjs.True})
+
+	ch, err := newChart(c, 400, 300)
+	if err != nil {
+		t.Fatalf("error making chart: %+v", err)
+	}
+	if r, b := ch.series[0], ch.series[1]; b.base == nil || b.base[1] != 2 || b.ys[1] != 6 {
+		t.Fatalf("expected blue to be stacked on red, got %v %v", r.ys, b.ys)
+	}
+	if g := ch.series[2]; g.base[0] != 0 {
+		t.Fatalf("expected green in its own stack, got %v", g.base)
+	}
+
+	var buf bytes.Buffer
+	if err := SVG(&buf, c, 400, 300); err != nil {
+		t.Fatalf("error rendering: %+v", err)
+	}
+	s := buf.String()
+	// blue has the highest Order so it is drawn first, under the others.
+	if b, r := strings.Index(s, `fill="rgba(0,0,255,1.000)"`), strings.Index(s, `fill="rgba(255,0,0,1.000)"`); b < 0 || r < 0 || b > r {
+		t.Fatalf("expected blue to be drawn before red")
+	}
+}
+
+func TestFillBetween(t *testing.T) {
+	green := &types.RGBA{0, 255, 0, 100}
+	c := chartjs.Chart{Type: chartjs.Line}
+	c.AddDataset(chartjs.Dataset{Data: chartjs.XY{X: []float64{0, 1, 2}, Y: []float64{1, 2, 1}}, Fill: chartjs.NoFill})
+	c.AddDataset(chartjs.Dataset{Data: chartjs.XY{X: []float64{0, 1, 2}, Y: []float64{3, 4, 3}}, Fill: chartjs.FillRelative(-1), BackgroundColor: green})
+
+	var buf bytes.Buffer
+	if err := SVG(&buf, c, 400, 300); err != nil {
+		t.Fatalf("error rendering: %+v", err)
+	}
+	if !strings.Contains(buf.String(), `fill="rgba(0,255,0,0.392)"`) {
+		t.Fatalf("expected the area between the lines to be filled")
+	}
+
+	c.Data.Datasets[1].Fill = chartjs.NoFill
+	buf.Reset()
+	if err := SVG(&buf, c, 400, 300); err != nil {
+		t.Fatalf("error rendering: %+v", err)
+	}
+	if strings.Contains(buf.String(), `fill="rgba(0,255,0,0.392)"`) {
+		t.Fatalf("expected no fill with NoFill")
+	}
+}
+
+func TestDashes(t *testing.T) {
+	d := dashes([]pt{{0, 0}, {10, 0}, {10, 10}}, []float64{4, 2}, 0)
+	if len(d) != 4 || d[0][1] != (pt{4, 0}) || d[1][1] != (pt{10, 0}) || len(d[1]) != 2 || d[2][0] != (pt{10, 2}) {
+		t.Fatalf("unexpected dashes: %v", d)
+	}
+	// the offset starts part way into the pattern.
+	if d := dashes([]pt{{0, 0}, {10, 0}}, []float64{4, 2}, 5); d[0][0] != (pt{1, 0}) {
+		t.Fatalf("unexpected dashes with offset: %v", d)
+	}
+	if d := dashes([]pt{{0, 0}, {10, 0}}, nil, 0); len(d) != 1 || len(d[0]) != 2 {
+		t.Fatalf("expected one solid line, got %v", d)
+	}
+}
+
+func TestLineStyles(t *testing.T) {
+	c := chartjs.Chart{Type: chartjs.Line}
+	c.AddDataset(chartjs.Dataset{Data: chartjs.XY{X: []float64{0, 1, 2}, Y: []float64{-1, 2, 1}}, BorderWidth: 2,
+		BorderDash: []float64{5, 3}, BorderCapStyle: chartjs.CapSquare})
+	c.AddYAxis(chartjs.Axis{Type: chartjs.Linear, GridLines: &chartjs.GridLineOptions{
+		Color: &types.RGBA{255, 0, 0, 255}, DrawTicks: chartjs.False, ZeroLineColor: &types.RGBA{0, 0, 255, 255}}})
+
+	var buf bytes.Buffer
+	if err := SVG(&buf, c, 400, 300); err != nil {
+		t.Fatalf("error rendering: %+v", err)
+	}
+	s := buf.String()
+	for _, want := range []string{`stroke-linecap="square" stroke-dasharray="5,3"`, `stroke="rgba(255,0,0,1.000)"`,
+		`stroke="rgba(0,0,255,1.000)"`} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("expected %s in svg", want)
+		}
+	}
+	if err := PNG(&bytes.Buffer{}, c, 400, 300); err != nil {
+		t.Fatalf("error rendering png: %+v", err)
+	}
+}
+
+func TestBarOptions(t *testing.T) {
+	pos, neg := types.RGBA{0, 0, 255, 255}, types.RGBA{255, 0, 0, 255}
+	ys := chartjs.Ys{2, -1}
+	c := chartjs.Chart{Type: chartjs.Bar, Data: chartjs.Data{Labels: []string{"a", "b"}}}
+	c.AddDataset(chartjs.Dataset{Data: ys, BackgroundColors: chartjs.SignColors(ys, pos, neg),
+		BarOptions: &chartjs.BarOptions{BarThickness: 10}})
+
+	ch, err := newChart(c, 400, 300)
+	if err != nil {
+		t.Fatalf("error making chart: %+v", err)
+	}
+	if _, w := ch.barGeometry(ch.series[0], 0, 0, 1); w != 10 {
+		t.Fatalf("expected a bar 10 pixels wide, got %v", w)
+	}
+	var buf bytes.Buffer
+	if err := SVG(&buf, c, 400, 300); err != nil {
+		t.Fatalf("error rendering: %+v", err)
+	}
+	if s := buf.String(); !strings.Contains(s, `fill="rgba(0,0,255,1.000)"`) || !strings.Contains(s, `fill="rgba(255,0,0,1.000)"`) {
+		t.Fatalf("expected a color for each sign")
+	}
+
+	r := []pt{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+	if b := barBorder(r, int(chartjs.SkipTop)); b[0] != r[2] || len(b) != 4 {
+		t.Fatalf("unexpected border skipping the top: %v", b)
+	}
+	if b := barBorder(r, int(chartjs.SkipNone)); len(b) != 5 {
+		t.Fatalf("expected a closed border, got %v", b)
+	}
+}
+
+func TestLogAxes(t *testing.T) {
+	c := chartjs.Chart{Type: chartjs.Line}
+	c.AddDataset(chartjs.Dataset{Data: chartjs.XY{X: []float64{0, 9, 99}, Y: []float64{-50, 0, 500}}})
+	c.AddXAxis(chartjs.Axis{Type: chartjs.Log, Position: chartjs.Bottom, Log: &chartjs.LogOptions{Safety: chartjs.LogPseudocount}})
+	c.AddYAxis(chartjs.Axis{Type: chartjs.Symlog, Position: chartjs.Left})
+
+	ch, err := newChart(c, 400, 300)
+	if err != nil {
+		t.Fatalf("error making chart: %+v", err)
+	}
+	s := ch.series[0]
+	if s.xs[0] != 1 || s.xs[2] != 100 || s.ys[0] >= 0 || s.ys[1] != 0 {
+		t.Fatalf("expected the values to be transformed, got %v %v", s.xs, s.ys)
+	}
+	var labels []string
+	for _, tk := range ch.yaxes[0].ticks {
+		labels = append(labels, tk.label)
+	}
+	if got := strings.Join(labels, " "); !strings.Contains(got, "-10 -1 0 1 10 100") {
+		t.Fatalf("unexpected symlog ticks: %s", got)
+	}
+}
+
+func TestBreaks(t *testing.T) {
+	c := chartjs.Chart{Type: chartjs.Bar, Data: chartjs.Data{Labels: []string{"a", "b"}}}
+	c.AddDataset(chartjs.Dataset{Data: chartjs.Ys{30, 1050}})
+	c.AddYAxis(chartjs.Axis{Type: chartjs.Linear, Breaks: []chartjs.Break{{From: 40, To: 1000}}})
+
+	ch, err := newChart(c, 400, 300)
+	if err != nil {
+		t.Fatalf("error making chart: %+v", err)
+	}
+	if ys := ch.series[0].ys; ys[1] != 90 {
+		t.Fatalf("expected the value above the break to be moved down, got %v", ys)
+	}
+	var labels []string
+	for _, tk := range ch.yaxes[0].ticks {
+		labels = append(labels, tk.label)
+	}
+	if got := strings.Join(labels, " "); !strings.Contains(got, "20 1020") {
+		t.Fatalf("unexpected ticks: %s", got)
+	}
+	var buf bytes.Buffer
+	if err := SVG(&buf, c, 400, 300); err != nil {
+		t.Fatalf("error rendering: %+v", err)
+	}
+	if !strings.Contains(buf.String(), `fill="rgba(255,255,255,1.000)"`) {
+		t.Fatalf("expected a zig-zag at the break")
+	}
+}
@@ -0,0 +1,102 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+
+	chartjs "github.com/brentp/go-chartjs"
+)
+
+// SVG writes the chart as an SVG image of the given size in pixels.
+func SVG(w io.Writer, c chartjs.Chart, width, height int) error {
+	ch, err := newChart(c, width, height)
+	if err != nil {
+		return err
+	}
+	s := &svg{}
+	fmt.Fprintf(&s.buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+	ch.draw(s)
+	s.buf.WriteString("</svg>\n")
+	_, err = s.buf.WriteTo(w)
+	return err
+}
+
+type svg struct {
+	buf bytes.Buffer
+}
+
+func svgColor(c color.NRGBA) string {
+	return fmt.Sprintf(`rgba(%d,%d,%d,%.3f)`, c.R, c.G, c.B, float64(c.A)/255)
+}
+
+func (s *svg) points(pts []pt) {
+	for i, p := range pts {
+		if i > 0 {
+			s.buf.WriteRune(' ')
+		}
+		fmt.Fprintf(&s.buf, "%.2f,%.2f", p.x, p.y)
+	}
+}
+
+func (s *svg) polyline(pts []pt, st stroke) {
+	if st.width <= 0 || st.color.A == 0 || len(pts) < 2 {
+		return
+	}
+	join, cap := st.join, st.cap
+	if join == "" {
+		join = "round"
+	}
+	fmt.Fprintf(&s.buf, `<polyline fill="none" stroke-linejoin="%s" `, join)
+	if cap != "" {
+		fmt.Fprintf(&s.buf, `stroke-linecap="%s" `, cap)
+	}
+	if len(st.dash) > 0 {
+		s.buf.WriteString(`stroke-dasharray="`)
+		for i, d := range st.dash {
+			if i > 0 {
+				s.buf.WriteRune(',')
+			}
+			fmt.Fprintf(&s.buf, "%g", d)
+		}
+		s.buf.WriteString(`" `)
+		if st.dashOffset != 0 {
+			fmt.Fprintf(&s.buf, `stroke-dashoffset="%g" `, st.dashOffset)
+		}
+	}
+	s.buf.WriteString(`points="`)
+	s.points(pts)
+	fmt.Fprintf(&s.buf, `" stroke="%s" stroke-width="%g"/>`+"\n", svgColor(st.color), st.width)
+}
+
+func (s *svg) polygon(pts []pt, fill color.NRGBA) {
+	if fill.A == 0 || len(pts) < 3 {
+		return
+	}
+	s.buf.WriteString(`<polygon points="`)
+	s.points(pts)
+	fmt.Fprintf(&s.buf, `" fill="%s"/>`+"\n", svgColor(fill))
+}
+
+func (s *svg) circle(c pt, r float64, fill color.NRGBA, st stroke) {
+	fmt.Fprintf(&s.buf, `<circle cx="%.2f" cy="%.2f" r="%.2f" fill="%s"`, c.x, c.y, r, svgColor(fill))
+	if st.width > 0 {
+		fmt.Fprintf(&s.buf, ` stroke="%s" stroke-width="%g"`, svgColor(st.color), st.width)
+	}
+	s.buf.WriteString("/>\n")
+}
+
+var anchors = [...]string{"start", "middle", "end"}
+
+func (s *svg) text(p pt, t string, c color.NRGBA, a anchor, vertical bool) {
+	fmt.Fprintf(&s.buf, `<text x="%.2f" y="%.2f" fill="%s" text-anchor="%s"`, p.x, p.y, svgColor(c), anchors[a])
+	if vertical {
+		fmt.Fprintf(&s.buf, ` transform="rotate(-90 %.2f %.2f)"`, p.x, p.y)
+	}
+	s.buf.WriteRune('>')
+	xml.EscapeText(&s.buf, []byte(t))
+	s.buf.WriteString("</text>\n")
+}
@@ -0,0 +1,550 @@
+// Package spec reads charts described in YAML or JSON files that reference delimited data files.
+//
+//	width: 600
+//	height: 400
+//	charts:
+//	  - type: scatter
+//	    title: Coverage
+//	    x: {label: depth, type: log}
+//	    y: {label: coverage, min: 0, max: 1}
+//	    datasets:
+//	      - file: coverage.tsv
+//	        x: depth
+//	        y: coverage
+//	        group: sample
+//
+// The chart types are line, scatter, bar, bubble, box, violin and hist. Axis types are linear,
+// log (values that aren't positive are dropped with a warning), log1p (log of the values plus 1 so
+// that zeros are shown), symlog (symmetric log for values that span zero) and, for x, time with
+// values in unix seconds. Columns are selected by name or 0-based index.
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	chartjs "github.com/brentp/go-chartjs"
+	"github.com/brentp/go-chartjs/stats"
+	"github.com/brentp/go-chartjs/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Page holds the charts written to one HTML page.
+type Page struct {
+	// Width and Height are the size of each chart in pixels.
+	Width  int     `yaml:"width"`
+	Height int     `yaml:"height"`
+	Output string  `yaml:"output"`
+	Charts []Chart `yaml:"charts"`
+
+	// Dir is the directory that the data files are relative to.
+	Dir string `yaml:"-"`
+	// Stdin is read for a data file named "-".
+	Stdin io.Reader `yaml:"-"`
+	// Stderr, if set, is written a line by Render for each warning of chartjs.Chart.Marshal, such
+	// as values dropped from a log axis.
+	Stderr io.Writer `yaml:"-"`
+}
+
+// Chart describes a chartjs.Chart.
+type Chart struct {
+	Type  string `yaml:"type"`
+	Title string `yaml:"title"`
+	// ID sets the id of the canvas.
+	ID string `yaml:"id"`
+	// Bins is the number of bins of a hist. Freedman-Diaconis binning is used if it is 0.
+	Bins     int       `yaml:"bins"`
+	Legend   *bool     `yaml:"legend"`
+	X        Axis      `yaml:"x"`
+	Y        Axis      `yaml:"y"`
+	Datasets []Dataset `yaml:"datasets"`
+
+	line int
+}
+
+// Axis describes a chartjs.Axis.
+type Axis struct {
+	Type     string   `yaml:"type"`
+	Label    string   `yaml:"label"`
+	Position string   `yaml:"position"`
+	Min      *float64 `yaml:"min"`
+	Max      *float64 `yaml:"max"`
+
+	line int
+}
+
+// Dataset describes the datasets read from a delimited file with chartjs.ReadDelimited.
+type Dataset struct {
+	File      string `yaml:"file"`
+	Delimiter string `yaml:"delimiter"`
+	Header    *bool  `yaml:"header"`
+	X         string `yaml:"x"`
+	Y         string `yaml:"y"`
+	R         string `yaml:"r"`
+	Group     string `yaml:"group"`
+	// Label and Color apply when the file is not split by Group. Color is "#rrggbb", "#rrggbbaa"
+	// or "rgba(r, g, b, a)".
+	Label string `yaml:"label"`
+	Color string `yaml:"color"`
+	// Fill is a chartjs.FillMode such as false, origin or -1.
+	Fill chartjs.FillMode `yaml:"fill"`
+	// Dash is the lengths of alternating dashes and gaps of a line, e.g. [5, 5].
+	Dash []float64 `yaml:"dash"`
+
+	line int
+}
+
+// errorf returns an error at the line of the spec, if it is known.
+func errorf(line int, format string, args ...interface{}) error {
+	if line > 0 {
+		format = fmt.Sprintf("spec: line %d: %s", line, format)
+	} else {
+		format = "spec: " + format
+	}
+	return fmt.Errorf(format, args...)
+}
+
+// decode decodes n into v, reporting keys that are not fields of v.
+func decode(n *yaml.Node, v interface{}) error {
+	if n.Kind == yaml.MappingNode {
+		t := reflect.TypeOf(v).Elem()
+		for i := 0; i < len(n.Content); i += 2 {
+			k := n.Content[i]
+			found := false
+			for j := 0; j < t.NumField(); j++ {
+				if t.Field(j).Tag.Get("yaml") == k.Value {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return errorf(k.Line, "unknown field %q", k.Value)
+			}
+		}
+	}
+	return n.Decode(v)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (p *Page) UnmarshalYAML(n *yaml.Node) error {
+	type plain Page
+	return decode(n, (*plain)(p))
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (c *Chart) UnmarshalYAML(n *yaml.Node) error {
+	type plain Chart
+	c.line = n.Line
+	return decode(n, (*plain)(c))
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (a *Axis) UnmarshalYAML(n *yaml.Node) error {
+	type plain Axis
+	a.line = n.Line
+	return decode(n, (*plain)(a))
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Dataset) UnmarshalYAML(n *yaml.Node) error {
+	type plain Dataset
+	d.line = n.Line
+	return decode(n, (*plain)(d))
+}
+
+// Read parses a spec in YAML or JSON.
+func Read(r io.Reader) (*Page, error) {
+	var p Page
+	if err := yaml.NewDecoder(r).Decode(&p); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("spec: no charts")
+		}
+		if strings.HasPrefix(err.Error(), "spec: ") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("spec: %s", err)
+	}
+	if len(p.Charts) == 0 {
+		return nil, fmt.Errorf("spec: no charts")
+	}
+	return &p, nil
+}
+
+// ReadFile parses the spec at path. The data files are relative to its directory and the
+// output defaults to its name with an .html extension.
+func ReadFile(path string) (*Page, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	p.Dir = filepath.Dir(path)
+	if p.Output == "" {
+		p.Output = strings.TrimSuffix(path, filepath.Ext(path)) + ".html"
+	} else if !filepath.IsAbs(p.Output) {
+		p.Output = filepath.Join(p.Dir, p.Output)
+	}
+	return p, nil
+}
+
+// Options returns the options for chartjs.SaveCharts.
+func (p *Page) Options() map[string]interface{} {
+	o := make(map[string]interface{})
+	if p.Width > 0 {
+		o["width"] = p.Width
+	}
+	if p.Height > 0 {
+		o["height"] = p.Height
+	}
+	return o
+}
+
+// Render writes the charts to w with chartjs.SaveCharts.
+func (p *Page) Render(w io.Writer) error {
+	charts, err := p.Build()
+	if err != nil {
+		return err
+	}
+	if p.Stderr != nil {
+		for i, c := range charts {
+			_, warnings, err := c.Marshal()
+			if err != nil {
+				return errorf(p.Charts[i].line, "%s", err)
+			}
+			for _, w := range warnings {
+				fmt.Fprintln(p.Stderr, errorf(p.Charts[i].line, "%s", w))
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := chartjs.SaveCharts(&buf, p.Options(), charts...); err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// Build reads the data and returns the charts of the page.
+func (p *Page) Build() ([]chartjs.Chart, error) {
+	charts := make([]chartjs.Chart, 0, len(p.Charts))
+	for i := range p.Charts {
+		c, err := p.Charts[i].build(p)
+		if err != nil {
+			return nil, err
+		}
+		charts = append(charts, c)
+	}
+	return charts, nil
+}
+
+var chartTypes = map[string]chartjs.Chart{
+	"line":    {Type: chartjs.Line},
+	"scatter": {Type: chartjs.Line},
+	"bar":     {Type: chartjs.Bar},
+	"bubble":  {Type: chartjs.Bubble},
+	"box":     {Type: chartjs.BoxPlot},
+	"violin":  {Type: chartjs.Violin},
+	"hist":    {Type: chartjs.Bar},
+}
+
+var axisTypes = map[string]chartjs.Axis{
+	"":       {Type: chartjs.Linear},
+	"linear": {Type: chartjs.Linear},
+	"log":    {Type: chartjs.Log, Log: &chartjs.LogOptions{Safety: chartjs.LogDrop}},
+	"log1p":  {Type: chartjs.Log, Log: &chartjs.LogOptions{Safety: chartjs.LogPseudocount}},
+	"symlog": {Type: chartjs.Symlog},
+	"time":   {Type: chartjs.Time},
+}
+
+// ParseColor parses "#rrggbb", "#rrggbbaa" or "rgba(r, g, b, a)" with a in [0, 1].
+func ParseColor(s string) (types.RGBA, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "#") && (len(s) == 7 || len(s) == 9) {
+		v, err := strconv.ParseUint(s[1:], 16, 32)
+		if err == nil {
+			if len(s) == 7 {
+				v = v<<8 | 0xff
+			}
+			return types.RGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+		}
+	}
+	if strings.HasPrefix(s, "rgba(") && strings.HasSuffix(s, ")") {
+		parts := strings.Split(s[5:len(s)-1], ",")
+		if len(parts) == 4 {
+			var c [4]float64
+			var err error
+			for i, p := range parts {
+				if c[i], err = strconv.ParseFloat(strings.TrimSpace(p), 64); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				return types.RGBA{R: uint8(c[0]), G: uint8(c[1]), B: uint8(c[2]), A: uint8(c[3]*255 + 0.5)}, nil
+			}
+		}
+	}
+	return types.RGBA{}, fmt.Errorf("bad color %q", s)
+}
+
+func (a Axis) build(name string) (chartjs.Axis, error) {
+	ax, ok := axisTypes[a.Type]
+	if !ok || (a.Type == "time" && name == "y") {
+		return ax, errorf(a.line, "unknown %s axis type %q", name, a.Type)
+	}
+	switch {
+	case a.Position == "" && name == "x":
+		ax.Position = chartjs.Bottom
+	case a.Position == "" && name == "y":
+		ax.Position = chartjs.Left
+	case a.Position == "bottom" && name == "x":
+		ax.Position = chartjs.Bottom
+	case a.Position == "top" && name == "x":
+		ax.Position = chartjs.Top
+	case a.Position == "left" && name == "y":
+		ax.Position = chartjs.Left
+	case a.Position == "right" && name == "y":
+		ax.Position = chartjs.Right
+	default:
+		return ax, errorf(a.line, "bad %s axis position %q", name, a.Position)
+	}
+	if a.Label != "" {
+		ax.ScaleLabel = &chartjs.ScaleLabel{Display: types.True, LabelString: a.Label}
+	}
+	if a.Min != nil || a.Max != nil {
+		ax.Tick = &chartjs.Tick{}
+		// chartjs.Tick leaves out a limit of 0, so the axis is made to begin at zero instead.
+		if a.Min != nil && a.Max != nil && *a.Min == 0 && *a.Max == 0 {
+			return ax, errorf(a.line, "%s axis min and max are both 0", name)
+		}
+		if a.Min != nil {
+			ax.Tick.Min = *a.Min
+			if *a.Min == 0 {
+				ax.Tick.BeginAtZero = types.True
+			}
+		}
+		if a.Max != nil {
+			ax.Tick.Max = *a.Max
+			if *a.Max == 0 {
+				ax.Tick.BeginAtZero = types.True
+			}
+		}
+	}
+	return ax, nil
+}
+
+// read returns the datasets in the file of d.
+func (d Dataset) read(p *Page) ([]chartjs.Dataset, error) {
+	if d.File == "" {
+		return nil, errorf(d.line, "dataset has no file")
+	}
+	o := chartjs.ReadOptions{X: d.X, Y: d.Y, R: d.R, Group: d.Group}
+	if o.Y == "" {
+		o.Y = "0"
+	}
+	if d.Header != nil {
+		o.Header = types.Bool(d.Header)
+	}
+	if d.Delimiter != "" {
+		r := []rune(d.Delimiter)
+		if d.Delimiter == `\t` {
+			r = []rune{'\t'}
+		}
+		if len(r) != 1 {
+			return nil, errorf(d.line, "delimiter must be a single character, got %q", d.Delimiter)
+		}
+		o.Comma = r[0]
+	}
+
+	var rdr io.Reader
+	if d.File == "-" {
+		if p.Stdin == nil {
+			return nil, errorf(d.line, "no stdin to read")
+		}
+		rdr = p.Stdin
+	} else {
+		path := d.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(p.Dir, path)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, errorf(d.line, "%s", err)
+		}
+		defer f.Close()
+		rdr = f
+	}
+	ds, err := chartjs.ReadDelimited(rdr, o)
+	if err != nil {
+		return nil, errorf(d.line, "%s: %s", d.File, err)
+	}
+	if len(ds) == 0 {
+		return nil, errorf(d.line, "%s: no rows", d.File)
+	}
+	if d.Group == "" && d.Label != "" {
+		ds[0].Label = d.Label
+	}
+	for i := range ds {
+		ds[i].Fill = d.Fill
+		ds[i].BorderDash = d.Dash
+	}
+	return ds, nil
+}
+
+// build reads the data of the chart and returns it as a chartjs.Chart.
+func (c *Chart) build(p *Page) (chartjs.Chart, error) {
+	chart, ok := chartTypes[c.Type]
+	if !ok {
+		return chart, errorf(c.line, "unknown chart type %q", c.Type)
+	}
+	if len(c.Datasets) == 0 {
+		return chart, errorf(c.line, "chart has no datasets")
+	}
+	chart.ID = c.ID
+	xa, err := c.X.build("x")
+	if err != nil {
+		return chart, err
+	}
+	ya, err := c.Y.build("y")
+	if err != nil {
+		return chart, err
+	}
+	if c.Title != "" {
+		chart.Options.Title = &chartjs.Title{Display: types.True, Text: c.Title}
+	}
+	if c.Legend != nil {
+		chart.Options.Legend = &chartjs.Legend{Display: types.Bool(c.Legend)}
+	}
+	chart.Options.Responsive = types.False
+
+	var datasets []chartjs.Dataset
+	for _, d := range c.Datasets {
+		ds, err := d.read(p)
+		if err != nil {
+			return chart, err
+		}
+		for _, s := range ds {
+			col := chartjs.Palette[len(datasets)%len(chartjs.Palette)]
+			if d.Color != "" && d.Group == "" {
+				if col, err = ParseColor(d.Color); err != nil {
+					return chart, errorf(d.line, "%s", err)
+				}
+			}
+			s.BackgroundColor, s.BorderColor = &col, &col
+			datasets = append(datasets, s)
+		}
+	}
+
+	switch c.Type {
+	case "hist":
+		var all []float64
+		for _, d := range datasets {
+			all = append(all, d.Data.Ys()...)
+		}
+		ho := &stats.HistOptions{Binning: stats.FreedmanDiaconis}
+		if c.Bins > 0 {
+			ho = &stats.HistOptions{Binning: stats.Explicit, N: c.Bins}
+		}
+		// the datasets share the bins of all of the values.
+		h, err := stats.Histogram(all, ho)
+		if err != nil {
+			return chart, errorf(c.line, "%s", err)
+		}
+		for _, d := range datasets {
+			hd, _, err := chartjs.Histogram(d.Data.Ys(), &stats.HistOptions{Breaks: h.Edges})
+			if err != nil {
+				return chart, errorf(c.line, "%s", err)
+			}
+			hd.Label, hd.BackgroundColor, hd.BorderColor = d.Label, d.BackgroundColor, d.BorderColor
+			chart.AddDataset(hd)
+		}
+		chart.Data.Labels = h.Labels()
+		xa = category(xa)
+		if ya.ScaleLabel == nil {
+			ya.ScaleLabel = &chartjs.ScaleLabel{Display: types.True, LabelString: "count"}
+		}
+	case "bar":
+		n := 0
+		for _, d := range datasets {
+			d.Data = chartjs.Ys(d.Data.Ys())
+			if l := len(d.Data.Ys()); l > n {
+				n = l
+			}
+			chart.AddDataset(d)
+		}
+		if x := datasets[0].Data.Xs(); len(x) == n {
+			for _, v := range x {
+				chart.Data.Labels = append(chart.Data.Labels, strconv.FormatFloat(v, 'g', -1, 64))
+			}
+		} else {
+			for i := 0; i < n; i++ {
+				chart.Data.Labels = append(chart.Data.Labels, strconv.Itoa(i))
+			}
+		}
+		xa = category(xa)
+	case "box", "violin":
+		var samples chartjs.RawSamples
+		for _, d := range datasets {
+			samples = append(samples, d.Data.Ys())
+			chart.Data.Labels = append(chart.Data.Labels, d.Label)
+		}
+		col := datasets[0].BackgroundColor
+		chart.AddDataset(chartjs.Dataset{Samples: samples, BackgroundColor: col, BorderColor: col})
+		if chart.Options.Legend == nil {
+			chart.Options.Legend = &chartjs.Legend{Display: types.False}
+		}
+		xa = category(xa)
+	default:
+		for i, d := range datasets {
+			xs, ys, rs := d.Data.Xs(), d.Data.Ys(), d.Data.Rs()
+			if c.Type == "bubble" && len(rs) == 0 {
+				return chart, errorf(c.line, "bubble charts require a column of radii")
+			}
+			if len(ys) == 0 {
+				return chart, errorf(c.line, "dataset %d has no values", i)
+			}
+			if len(xs) == 0 {
+				xs = stats.Linspace(0, float64(len(ys)-1), len(ys))
+			}
+			if c.X.Type == "time" {
+				ms := make([]float64, len(xs))
+				for j, x := range xs {
+					ms[j] = x * 1000
+				}
+				xs = ms
+			}
+			d.Data = chartjs.XYR{X: xs, Y: ys, R: rs}
+			if c.Type == "scatter" {
+				d.ShowLine = types.False
+				d.PointRadius = 3
+			}
+			if c.Type != "bubble" && d.Fill == "" {
+				d.Fill = chartjs.NoFill
+			}
+			datasets[i] = d
+			chart.AddDataset(d)
+		}
+	}
+
+	if _, err := chart.AddXAxis(xa); err != nil {
+		return chart, err
+	}
+	_, err = chart.AddYAxis(ya)
+	return chart, err
+}
+
+// category returns a category axis in the place of a.
+func category(a chartjs.Axis) chartjs.Axis {
+	a.Type = chartjs.Category
+	a.Tick = nil
+	return a
+}
@@ -0,0 +1,154 @@
+package spec
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+const data = "x\ty\tgroup\n1\t2\tA\n2\t4\tB\n3\t8\tA\n"
+
+const page = `width: 300
+height: 200
+output: out.html
+charts:
+  - type: scatter
+    title: Scatter
+    id: scatter
+    x: {type: log, label: depth}
+    y: {label: coverage, position: right, max: 10}
+    datasets:
+      - file: data.tsv
+        x: x
+        y: y
+        group: group
+  - type: line
+    legend: false
+    y: {min: 0}
+    datasets:
+      - file: data.tsv
+        x: 0
+        y: 1
+        label: first
+        color: "#ff000080"
+        dash: [5, 5]
+`
+
+func write(t *testing.T, dir, name, content string) string {
+	p := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestReadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write(t, dir, "data.tsv", data)
+
+	p, err := ReadFile(write(t, dir, "page.yaml", page))
+	if err != nil {
+		t.Fatalf("error reading spec: %+v", err)
+	}
+	if p.Output != filepath.Join(dir, "out.html") {
+		t.Fatalf("expected the output to be relative to the spec, got %s", p.Output)
+	}
+	charts, err := p.Build()
+	if err != nil {
+		t.Fatalf("error building charts: %+v", err)
+	}
+	if len(charts) != 2 || len(charts[0].Data.Datasets) != 2 || charts[0].ID != "scatter" {
+		t.Fatalf("unexpected charts: %+v", charts)
+	}
+	if d := charts[1].Data.Datasets[0]; d.Label != "first" || *d.BorderColor != (types.RGBA{R: 255, A: 128}) || len(d.BorderDash) != 2 {
+		t.Fatalf("unexpected dataset: %+v", d)
+	}
+
+	var buf bytes.Buffer
+	if err := p.Render(&buf); err != nil {
+		t.Fatalf("error rendering: %+v", err)
+	}
+	for _, want := range []string{`id="scatter"`, "width:300px", `"type":"logarithmic"`, `"position":"right"`,
+		`"ticks":{"max":10}`, `"label":"B"`, `"text":"Scatter"`, `"legend":{"display":false}`, `"ticks":{"beginAtZero":true}`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("expected %q in page", want)
+		}
+	}
+
+	// JSON is also YAML.
+	js := `{"charts": [{"type": "box", "datasets": [{"file": "data.tsv", "y": "y", "group": "group"}]}]}`
+	p, err = ReadFile(write(t, dir, "box.json", js))
+	if err != nil {
+		t.Fatalf("error reading json spec: %+v", err)
+	}
+	if p.Output != filepath.Join(dir, "box.html") {
+		t.Fatalf("unexpected default output: %s", p.Output)
+	}
+	if charts, err = p.Build(); err != nil || len(charts[0].Data.Labels) != 2 {
+		t.Fatalf("unexpected box chart: %+v %v", charts, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write(t, dir, "data.tsv", data)
+	write(t, dir, "bad.tsv", "x\ty\n1\t2\n2\tz\n")
+	write(t, dir, "header.tsv", "x\ty\n")
+
+	for _, c := range []struct {
+		spec string
+		want string
+	}{
+		{"charts:\n  - type: line\n    colour: red\n", "line 3: unknown field \"colour\""},
+		{"charts:\n  - type: pie\n    datasets:\n      - file: data.tsv\n", "line 2: unknown chart type \"pie\""},
+		{"charts:\n  - type: line\n    y: {min: 0, max: 0}\n    datasets:\n      - file: data.tsv\n", "line 3: y axis min and max are both 0"},
+		{"charts:\n  - type: line\n    y: {type: time}\n    datasets:\n      - file: data.tsv\n", "line 3: unknown y axis type"},
+		{"charts:\n  - type: line\n    datasets:\n      - file: data.tsv\n        color: blue\n", "line 4: bad color"},
+		{"charts:\n  - type: line\n    datasets:\n      - file: bad.tsv\n        x: x\n        y: y\n", "line 4: bad.tsv: chart: line 3, column 2"},
+		{"charts:\n  - type: line\n    datasets:\n      - file: missing.tsv\n", "line 4: open"},
+		{"charts:\n  - type: bar\n    datasets:\n      - file: header.tsv\n        label: l\n", "line 4: header.tsv: chart: no rows"},
+		{"charts:\n  - type: line\n    bins: many\n", "line 3: cannot unmarshal"},
+		{"width: 100\n", "no charts"},
+	} {
+		p, err := Read(strings.NewReader(c.spec))
+		if err == nil {
+			p.Dir = dir
+			_, err = p.Build()
+		}
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Fatalf("expected error containing %q, got %v", c.want, err)
+		}
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	for s, want := range map[string]types.RGBA{
+		"#66c2a5":            {R: 102, G: 194, B: 165, A: 255},
+		"#66c2a580":          {R: 102, G: 194, B: 165, A: 128},
+		"rgba(1, 2, 3, 0.5)": {R: 1, G: 2, B: 3, A: 128},
+		" rgba(1,2,3,1) ":    {R: 1, G: 2, B: 3, A: 255},
+	} {
+		c, err := ParseColor(s)
+		if err != nil || c != want {
+			t.Fatalf("%q: expected %v, got %v (%v)", s, want, c, err)
+		}
+	}
+	for _, s := range []string{"red", "#12345", "rgba(1, 2, 3)", "#gg0000"} {
+		if _, err := ParseColor(s); err == nil {
+			t.Fatalf("expected an error for %q", s)
+		}
+	}
+}
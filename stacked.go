@@ -0,0 +1,138 @@
+package chartjs
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/brentp/go-chartjs/types"
+)
+
+// Series is a labelled set of values for StackedBars and StackedArea.
+type Series struct {
+	Label string
+	// Categories names the category of each value. If it is nil, the values are in the
+	// categories "0", "1", ...
+	Categories []string
+	Values     []float64
+	// Color is used for the bars or area. If it is nil, a color is taken from Palette.
+	Color *types.RGBA
+}
+
+// StackOptions determines how StackedBars and StackedArea stack the series.
+type StackOptions struct {
+	// Percent normalizes each category so that the stack sums to 100. The values must not be negative.
+	Percent bool
+}
+
+// stack aligns the series by category and returns the categories and the values of each series in them.
+func stack(series []Series, o *StackOptions) ([]string, [][]float64, error) {
+	if len(series) == 0 {
+		return nil, nil, fmt.Errorf("chart: no series to stack")
+	}
+	var categories []string
+	index := make(map[string]int)
+	for i, s := range series {
+		if s.Categories != nil && len(s.Categories) != len(s.Values) {
+			return nil, nil, fmt.Errorf("chart: series %d has %d categories for %d values", i, len(s.Categories), len(s.Values))
+		}
+		seen := make(map[string]bool, len(s.Values))
+		for j := range s.Values {
+			c := strconv.Itoa(j)
+			if s.Categories != nil {
+				c = s.Categories[j]
+			}
+			if seen[c] {
+				return nil, nil, fmt.Errorf("chart: series %d has category %q more than once", i, c)
+			}
+			seen[c] = true
+			if _, ok := index[c]; !ok {
+				index[c] = len(categories)
+				categories = append(categories, c)
+			}
+		}
+	}
+
+	values := make([][]float64, len(series))
+	for i, s := range series {
+		// a category that is missing from a series is 0 so that the stack is unbroken.
+		values[i] = make([]float64, len(categories))
+		for j, v := range s.Values {
+			c := strconv.Itoa(j)
+			if s.Categories != nil {
+				c = s.Categories[j]
+			}
+			if o != nil && o.Percent && v < 0 {
+				return nil, nil, fmt.Errorf("chart: series %d has negative value %v in category %q", i, v, c)
+			}
+			values[i][index[c]] = v
+		}
+	}
+	if o != nil && o.Percent {
+		for j := range categories {
+			var sum float64
+			for i := range values {
+				sum += values[i][j]
+			}
+			if sum == 0 {
+				continue
+			}
+			for i := range values {
+				values[i][j] *= 100 / sum
+			}
+		}
+	}
+	return categories, values, nil
+}
+
+// stacked returns a chart of the aligned series with both axes stacked.
+func stacked(t chartType, series []Series, o *StackOptions) (Chart, error) {
+	categories, values, err := stack(series, o)
+	if err != nil {
+		return Chart{}, err
+	}
+	c := Chart{Type: t}
+	c.Data.Labels = categories
+	for i, s := range series {
+		col := s.Color
+		if col == nil {
+			p := Palette[i%len(Palette)]
+			col = &p
+		}
+		c.AddDataset(Dataset{Data: Ys(values[i]), Label: s.Label, BackgroundColor: col, BorderColor: col})
+	}
+	if _, err := c.AddXAxis(Axis{Type: Category, Position: Bottom, Stacked: True}); err != nil {
+		return c, err
+	}
+	y := Axis{Type: Linear, Position: Left, Stacked: True}
+	if o != nil && o.Percent {
+		y.Tick = &Tick{Min: 0, Max: 100, BeginAtZero: True}
+		y.ScaleLabel = &ScaleLabel{Display: True, LabelString: "%"}
+	}
+	_, err = c.AddYAxis(y)
+	return c, err
+}
+
+// StackedBars returns a Bar chart with the series stacked in each category. The categories of
+// the series are aligned in the order that they are first seen and a missing value is 0.
+func StackedBars(series []Series, o *StackOptions) (Chart, error) {
+	return stacked(Bar, series, o)
+}
+
+// StackedArea returns a Line chart with the series stacked as areas. The first series is filled
+// to the origin and each of the others to the series before it.
+func StackedArea(series []Series, o *StackOptions) (Chart, error) {
+	c, err := stacked(Line, series, o)
+	if err != nil {
+		return c, err
+	}
+	for i := range c.Data.Datasets {
+		d := &c.Data.Datasets[i]
+		d.Fill = FillRelative(-1)
+		if i == 0 {
+			d.Fill = FillOrigin
+		}
+		d.PointRadius = 0
+		d.PointHitRadius = 5
+	}
+	return c, nil
+}
@@ -0,0 +1,73 @@
+package chartjs
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestStackedBars(t *testing.T) {
+	series := []Series{
+		{Label: "a", Categories: []string{"x", "y"}, Values: []float64{1, 3}},
+		{Label: "b", Categories: []string{"z", "x"}, Values: []float64{2, 3}},
+	}
+	c, err := StackedBars(series, nil)
+	if err != nil {
+		t.Fatalf("error stacking: %+v", err)
+	}
+	if strings.Join(c.Data.Labels, ",") != "x,y,z" {
+		t.Fatalf("expected aligned categories, got %v", c.Data.Labels)
+	}
+	if b := c.Data.Datasets[1].Data.Ys(); b[0] != 3 || b[1] != 0 || b[2] != 2 {
+		t.Fatalf("unexpected aligned values: %v", b)
+	}
+	if *c.Options.Scales.XAxes[0].Stacked != true || *c.Options.Scales.YAxes[0].Stacked != true {
+		t.Fatalf("expected both axes to be stacked")
+	}
+
+	c, err = StackedBars(series, &StackOptions{Percent: true})
+	if err != nil {
+		t.Fatalf("error stacking: %+v", err)
+	}
+	for j := range c.Data.Labels {
+		var sum float64
+		for _, d := range c.Data.Datasets {
+			sum += d.Data.Ys()[j]
+		}
+		if math.Abs(sum-100) > 1e-9 {
+			t.Fatalf("expected category %d to sum to 100, got %v", j, sum)
+		}
+	}
+
+	for _, s := range [][]Series{
+		nil,
+		{{Categories: []string{"x"}, Values: []float64{1, 2}}},
+		{{Categories: []string{"x", "x"}, Values: []float64{1, 2}}},
+	} {
+		if _, err := StackedBars(s, nil); err == nil {
+			t.Fatalf("expected an error for %+v", s)
+		}
+	}
+	if _, err := StackedBars([]Series{{Values: []float64{-1}}}, &StackOptions{Percent: true}); err == nil {
+		t.Fatalf("expected an error for a negative percent")
+	}
+}
+
+func TestStackedArea(t *testing.T) {
+	c, err := StackedArea([]Series{{Label: "a", Values: []float64{1, 2}}, {Label: "b", Values: []float64{3}}, {Label: "c", Values: []float64{1, 1}}}, nil)
+	if err != nil {
+		t.Fatalf("error stacking: %+v", err)
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling: %+v", err)
+	}
+	s := string(b)
+	if strings.Count(s, `"fill":"-1"`) != 2 || strings.Count(s, `"fill":"origin"`) != 1 || !strings.HasPrefix(s, `{"type":"line"`) {
+		t.Fatalf("unexpected fills: %s", s)
+	}
+	if !strings.Contains(s, `"labels":["0","1"]`) {
+		t.Fatalf("expected index categories in %s", s)
+	}
+}
@@ -0,0 +1,48 @@
+package stats
+
+import "fmt"
+
+// Box summarizes a distribution as drawn by a box plot.
+type Box struct {
+	Min, Q1, Median, Q3, Max float64
+	// WhiskerMin and WhiskerMax are the ends of the whiskers.
+	WhiskerMin, WhiskerMax float64
+	// Outliers are the values beyond the whiskers.
+	Outliers []float64
+}
+
+// Summarize returns the Box of vals. If tukey is true, the whiskers extend to the most extreme
+// values within 1.5 IQR of the quartiles and values beyond them are Outliers. Otherwise the
+// whiskers extend to the minimum and maximum. NaN and infinite values are ignored.
+func Summarize(vals []float64, tukey bool) (Box, error) {
+	sorted := Sorted(vals)
+	if len(sorted) == 0 {
+		return Box{}, fmt.Errorf("stats: no values to summarize")
+	}
+	b := Box{
+		Min:    sorted[0],
+		Q1:     Quantile(sorted, 0.25),
+		Median: Quantile(sorted, 0.5),
+		Q3:     Quantile(sorted, 0.75),
+		Max:    sorted[len(sorted)-1],
+	}
+	b.WhiskerMin, b.WhiskerMax = b.Min, b.Max
+	if !tukey {
+		return b, nil
+	}
+	lo, hi := b.Q1-1.5*(b.Q3-b.Q1), b.Q3+1.5*(b.Q3-b.Q1)
+	b.WhiskerMin, b.WhiskerMax = b.Q1, b.Q3
+	for _, v := range sorted {
+		if v < lo || v > hi {
+			b.Outliers = append(b.Outliers, v)
+			continue
+		}
+		if v < b.WhiskerMin {
+			b.WhiskerMin = v
+		}
+		if v > b.WhiskerMax {
+			b.WhiskerMax = v
+		}
+	}
+	return b, nil
+}
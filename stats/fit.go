@@ -0,0 +1,253 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// byX returns copies of xs and ys sorted by x with pairs containing NaN removed.
+func byX(xs, ys []float64) ([]float64, []float64, error) {
+	if len(xs) != len(ys) {
+		return nil, nil, fmt.Errorf("stats: X and Y must be of the same length")
+	}
+	idx := make([]int, 0, len(xs))
+	for i := range xs {
+		if !math.IsNaN(xs[i]) && !math.IsNaN(ys[i]) {
+			idx = append(idx, i)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return xs[idx[a]] < xs[idx[b]] })
+	sx, sy := make([]float64, len(idx)), make([]float64, len(idx))
+	for k, i := range idx {
+		sx[k], sy[k] = xs[i], ys[i]
+	}
+	return sx, sy, nil
+}
+
+// Poly evaluates the polynomial with coefficients in increasing order of degree at x.
+func Poly(coef []float64, x float64) float64 {
+	var y float64
+	for i := len(coef) - 1; i >= 0; i-- {
+		y = y*x + coef[i]
+	}
+	return y
+}
+
+// PolyFit returns the least squares coefficients, in increasing order of degree, of the
+// polynomial of the given degree through xs and ys along with the R² of the fit.
+// Pairs containing NaN are ignored.
+func PolyFit(xs, ys []float64, degree int) (coef []float64, r2 float64, err error) {
+	xs, ys, err = byX(xs, ys)
+	if err != nil {
+		return nil, 0, err
+	}
+	if degree < 0 {
+		return nil, 0, fmt.Errorf("stats: degree must be >= 0")
+	}
+	n := degree + 1
+	if len(xs) < n {
+		return nil, 0, fmt.Errorf("stats: %d points are too few to fit a polynomial of degree %d", len(xs), degree)
+	}
+	// x is centered and scaled to [-1, 1] so that the system is well conditioned for x such
+	// as years, and the coefficients are mapped back to x after solving.
+	mid, half := (xs[0]+xs[len(xs)-1])/2, (xs[len(xs)-1]-xs[0])/2
+	if half == 0 {
+		half = 1
+	}
+	zs := make([]float64, len(xs))
+	for k, x := range xs {
+		zs[k] = (x - mid) / half
+	}
+	// build the normal equations A c = b with A[i][j] = sum(z^(i+j)) and b[i] = sum(y z^i).
+	pows := make([]float64, 2*n-1)
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n+1)
+	}
+	for k, z := range zs {
+		p := 1.0
+		for i := range pows {
+			pows[i] += p
+			if i < n {
+				a[i][n] += ys[k] * p
+			}
+			p *= z
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a[i][j] = pows[i+j]
+		}
+	}
+	zcoef, err := solve(a)
+	if err != nil {
+		return nil, 0, err
+	}
+	// expand sum(c_k ((x - mid) / half)^k) into powers of x.
+	coef = make([]float64, n)
+	for k, c := range zcoef {
+		c /= math.Pow(half, float64(k))
+		binom := 1.0
+		for j := k; j >= 0; j-- {
+			coef[j] += c * binom * math.Pow(-mid, float64(k-j))
+			binom = binom * float64(j) / float64(k-j+1)
+		}
+	}
+
+	mean, _ := MeanStd(ys)
+	var ssres, sstot float64
+	for k, z := range zs {
+		r := ys[k] - Poly(zcoef, z)
+		ssres += r * r
+		sstot += (ys[k] - mean) * (ys[k] - mean)
+	}
+	r2 = 1
+	if sstot > 0 {
+		r2 = 1 - ssres/sstot
+	}
+	return coef, r2, nil
+}
+
+// solve solves the augmented system a by gaussian elimination with partial pivoting.
+func solve(a [][]float64) ([]float64, error) {
+	n := len(a)
+	for c := 0; c < n; c++ {
+		p := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(a[r][c]) > math.Abs(a[p][c]) {
+				p = r
+			}
+		}
+		if a[p][c] == 0 {
+			return nil, fmt.Errorf("stats: singular system; are there enough distinct X values?")
+		}
+		a[c], a[p] = a[p], a[c]
+		for r := c + 1; r < n; r++ {
+			f := a[r][c] / a[c][c]
+			for k := c; k <= n; k++ {
+				a[r][k] -= f * a[c][k]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		s := a[r][n]
+		for k := r + 1; k < n; k++ {
+			s -= a[r][k] * x[k]
+		}
+		x[r] = s / a[r][r]
+	}
+	return x, nil
+}
+
+// Lowess returns the locally weighted regression of ys on xs evaluated at each x in sorted order.
+// frac is the fraction of points used for each local fit (0.66 is common) and iters is the
+// number of robustifying iterations that down-weight outliers (3 is common).
+func Lowess(xs, ys []float64, frac float64, iters int) (*Curve, error) {
+	xs, ys, err := byX(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+	if frac <= 0 || frac > 1 {
+		return nil, fmt.Errorf("stats: lowess fraction must be in (0, 1]")
+	}
+	n := len(xs)
+	if n < 2 {
+		return nil, fmt.Errorf("stats: too few points for lowess")
+	}
+	k := int(math.Ceil(frac * float64(n)))
+	if k < 2 {
+		k = 2
+	}
+	robust := make([]float64, n)
+	for i := range robust {
+		robust[i] = 1
+	}
+	fit := make([]float64, n)
+	w := make([]float64, n)
+	for it := 0; it <= iters; it++ {
+		lo := 0
+		for i, x := range xs {
+			// slide the window of the k nearest neighbors.
+			for lo+k < n && x-xs[lo] > xs[lo+k]-x {
+				lo++
+			}
+			h := math.Max(x-xs[lo], xs[lo+k-1]-x)
+			var sw, swx, swy, swxx, swxy float64
+			for j := lo; j < lo+k; j++ {
+				w[j] = robust[j]
+				if h > 0 {
+					d := math.Abs(xs[j]-x) / (h * 1.000001)
+					w[j] *= math.Pow(1-d*d*d, 3)
+				}
+				sw += w[j]
+				swx += w[j] * xs[j]
+				swy += w[j] * ys[j]
+				swxx += w[j] * xs[j] * xs[j]
+				swxy += w[j] * xs[j] * ys[j]
+			}
+			if sw == 0 {
+				fit[i] = ys[i]
+				continue
+			}
+			mx, my := swx/sw, swy/sw
+			v := swxx/sw - mx*mx
+			if v <= 1e-12*math.Max(1, mx*mx) {
+				fit[i] = my
+				continue
+			}
+			b := (swxy/sw - mx*my) / v
+			fit[i] = my + b*(x-mx)
+		}
+		if it == iters {
+			break
+		}
+		resid := make([]float64, n)
+		for i := range resid {
+			resid[i] = math.Abs(ys[i] - fit[i])
+		}
+		med := Quantile(Sorted(resid), 0.5)
+		if med == 0 {
+			break
+		}
+		for i, r := range resid {
+			u := r / (6 * med)
+			if u < 1 {
+				robust[i] = (1 - u*u) * (1 - u*u)
+			} else {
+				robust[i] = 0
+			}
+		}
+	}
+	return &Curve{X: xs, Y: fit}, nil
+}
+
+// MovingAverage returns the centered moving average of ys, sorted by xs, over window points.
+// The window is truncated at the ends.
+func MovingAverage(xs, ys []float64, window int) (*Curve, error) {
+	xs, ys, err := byX(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+	if window < 1 {
+		return nil, fmt.Errorf("stats: moving average window must be >= 1")
+	}
+	c := &Curve{X: xs, Y: make([]float64, len(ys))}
+	half := window / 2
+	for i := range ys {
+		lo, hi := i-half, i-half+window
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(ys) {
+			hi = len(ys)
+		}
+		var s float64
+		for _, y := range ys[lo:hi] {
+			s += y
+		}
+		c.Y[i] = s / float64(hi-lo)
+	}
+	return c, nil
+}
@@ -0,0 +1,181 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+)
+
+// Binning determines how the number of bins in a histogram is chosen.
+type Binning int
+
+const (
+	// Sturges uses ceil(log2(n)) + 1 bins (this is the default).
+	Sturges Binning = iota
+	// Scott uses bins of width 3.49 * sd * n^(-1/3).
+	Scott
+	// FreedmanDiaconis uses bins of width 2 * IQR * n^(-1/3).
+	FreedmanDiaconis
+	// Explicit uses HistOptions.N bins.
+	Explicit
+)
+
+// HistOptions determines how a Histogram is binned.
+type HistOptions struct {
+	Binning Binning
+	// N is the number of bins used with Explicit.
+	N int
+	// Breaks are the edges of the bins. If set, these are used instead of Binning.
+	Breaks []float64
+	// Min and Max limit the range of the histogram. If nil, the range of the data is used.
+	// Values outside the range are not counted.
+	Min *float64
+	Max *float64
+	// Density normalizes the histogram so that its area is 1.
+	Density bool
+}
+
+// Hist is a binned histogram. It satisfies chartjs.Values as the values of a Bar chart.
+type Hist struct {
+	// Edges holds the len(Counts) + 1 edges of the bins.
+	Edges []float64
+	// Counts holds the count, or density, in each bin.
+	Counts []float64
+}
+
+// Xs satisfies chartjs.Values. It returns the bin counts.
+func (h *Hist) Xs() []float64 {
+	return h.Counts
+}
+
+// Ys satisfies chartjs.Values.
+func (h *Hist) Ys() []float64 {
+	return nil
+}
+
+// Rs satisfies chartjs.Values.
+func (h *Hist) Rs() []float64 {
+	return nil
+}
+
+// Mids returns the midpoint of each bin.
+func (h *Hist) Mids() []float64 {
+	mids := make([]float64, len(h.Counts))
+	for i := range mids {
+		mids[i] = (h.Edges[i] + h.Edges[i+1]) / 2
+	}
+	return mids
+}
+
+// Labels returns a "lo-hi" label for each bin to be used as chartjs.Data.Labels.
+func (h *Hist) Labels() []string {
+	labels := make([]string, len(h.Counts))
+	for i := range labels {
+		lo, hi := h.Edges[i], h.Edges[i+1]
+		if hi-lo < 2 {
+			labels[i] = fmt.Sprintf("%.2f-%.2f", lo, hi)
+		} else {
+			labels[i] = fmt.Sprintf("%.0f-%.0f", lo, hi)
+		}
+	}
+	return labels
+}
+
+// Histogram bins vals according to opts. NaN and infinite values are
+// ignored. If opts is nil, Sturges' rule is used over the range of the data.
+func Histogram(vals []float64, opts *HistOptions) (*Hist, error) {
+	if opts == nil {
+		opts = &HistOptions{}
+	}
+	sorted := Sorted(vals)
+	if len(sorted) == 0 {
+		return nil, fmt.Errorf("stats: no values for histogram")
+	}
+	edges := opts.Breaks
+	if len(edges) == 0 {
+		var err error
+		if edges, err = opts.edges(sorted); err != nil {
+			return nil, err
+		}
+	} else {
+		if len(edges) < 2 {
+			return nil, fmt.Errorf("stats: at least 2 histogram breaks are required")
+		}
+		for i := 1; i < len(edges); i++ {
+			if edges[i] <= edges[i-1] {
+				return nil, fmt.Errorf("stats: histogram breaks must be increasing")
+			}
+		}
+	}
+
+	h := &Hist{Edges: edges, Counts: make([]float64, len(edges)-1)}
+	lo, hi := edges[0], edges[len(edges)-1]
+	var n int
+	j := 0
+	for _, v := range sorted {
+		if v < lo || v > hi {
+			continue
+		}
+		// bins are [lo, hi) except the last which includes the maximum.
+		for j < len(h.Counts)-1 && v >= edges[j+1] {
+			j++
+		}
+		h.Counts[j]++
+		n++
+	}
+	if opts.Density && n > 0 {
+		for i := range h.Counts {
+			h.Counts[i] /= float64(n) * (edges[i+1] - edges[i])
+		}
+	}
+	return h, nil
+}
+
+// edges returns the bin edges for the sorted values.
+func (o *HistOptions) edges(sorted []float64) ([]float64, error) {
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if o.Min != nil {
+		lo = *o.Min
+	}
+	if o.Max != nil {
+		hi = *o.Max
+	}
+	if hi < lo {
+		return nil, fmt.Errorf("stats: histogram Max (%g) is less than Min (%g)", hi, lo)
+	}
+	if hi == lo {
+		// widen a range of 0 so that the bins have a width.
+		lo, hi = lo-0.5, hi+0.5
+	}
+
+	n := float64(len(sorted))
+	var width float64
+	switch o.Binning {
+	case Scott:
+		_, std := MeanStd(sorted)
+		width = 3.49 * std * math.Pow(n, -1.0/3)
+	case FreedmanDiaconis:
+		width = 2 * (Quantile(sorted, 0.75) - Quantile(sorted, 0.25)) * math.Pow(n, -1.0/3)
+	case Explicit:
+		if o.N < 1 {
+			return nil, fmt.Errorf("stats: Explicit binning requires N > 0")
+		}
+	}
+	var bins int
+	switch r := (hi - lo) / width; {
+	case o.Binning == Explicit:
+		bins = o.N
+	case width > 0 && r <= n:
+		bins = int(math.Ceil(r))
+	default:
+		// Sturges, or a spread of 0 for the other rules, or more bins than values when a few
+		// outliers are far from the rest.
+		bins = int(math.Ceil(math.Log2(n))) + 1
+	}
+	if bins < 1 {
+		bins = 1
+	}
+	edges := Linspace(lo, hi, bins+1)
+	// avoid floating point error excluding the maximum.
+	edges[bins] = hi
+	return edges, nil
+}
@@ -0,0 +1,166 @@
+// Package stats computes summaries of data (histograms, densities and ECDFs) that are ready to
+// plot with chartjs. The returned types satisfy the chartjs.Values interface.
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Curve holds the X and Y values of a curve such as a density or ECDF.
+type Curve struct {
+	X []float64
+	Y []float64
+}
+
+// Xs satisfies chartjs.Values.
+func (c *Curve) Xs() []float64 {
+	return c.X
+}
+
+// Ys satisfies chartjs.Values.
+func (c *Curve) Ys() []float64 {
+	return c.Y
+}
+
+// Rs satisfies chartjs.Values.
+func (c *Curve) Rs() []float64 {
+	return nil
+}
+
+// Sorted returns a sorted copy of vals with NaNs and infinities removed.
+func Sorted(vals []float64) []float64 {
+	s := make([]float64, 0, len(vals))
+	for _, v := range vals {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			s = append(s, v)
+		}
+	}
+	sort.Float64s(s)
+	return s
+}
+
+// Quantile returns the p-th quantile of the sorted values using linear interpolation
+// between the closest ranks. It returns NaN if sorted is empty.
+func Quantile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	if p <= 0 {
+		return sorted[0]
+	}
+	if p >= 1 {
+		return sorted[len(sorted)-1]
+	}
+	h := p * float64(len(sorted)-1)
+	lo := math.Floor(h)
+	i := int(lo)
+	if i+1 >= len(sorted) {
+		return sorted[i]
+	}
+	return sorted[i] + (h-lo)*(sorted[i+1]-sorted[i])
+}
+
+// MeanStd returns the mean and sample standard deviation of vals.
+func MeanStd(vals []float64) (mean, std float64) {
+	if len(vals) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= float64(len(vals))
+	if len(vals) == 1 {
+		return mean, 0
+	}
+	for _, v := range vals {
+		std += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(std / float64(len(vals)-1))
+}
+
+// Silverman returns the bandwidth for a gaussian kernel density estimate by Silverman's
+// rule of thumb.
+func Silverman(sorted []float64) float64 {
+	_, std := MeanStd(sorted)
+	iqr := Quantile(sorted, 0.75) - Quantile(sorted, 0.25)
+	s := std
+	if iqr > 0 && iqr/1.34 < s {
+		s = iqr / 1.34
+	}
+	return 0.9 * s * math.Pow(float64(len(sorted)), -0.2)
+}
+
+// KDE returns a gaussian kernel density estimate of vals evaluated at n points. If bandwidth
+// is <= 0, it is chosen by Silverman's rule. If n <= 0, 512 points are used. The curve extends
+// 3 bandwidths past the extremes of the data.
+func KDE(vals []float64, bandwidth float64, n int) (*Curve, error) {
+	sorted := Sorted(vals)
+	if len(sorted) == 0 {
+		return nil, fmt.Errorf("stats: no values for KDE")
+	}
+	if bandwidth <= 0 {
+		bandwidth = Silverman(sorted)
+	}
+	if bandwidth <= 0 {
+		// all values are equal so use an arbitrary width.
+		bandwidth = 1
+	}
+	lo, hi := sorted[0]-3*bandwidth, sorted[len(sorted)-1]+3*bandwidth
+	return KDEAt(sorted, bandwidth, Linspace(lo, hi, n)), nil
+}
+
+// KDEAt returns a gaussian kernel density estimate of vals with the given bandwidth
+// evaluated at xs.
+func KDEAt(vals []float64, bandwidth float64, xs []float64) *Curve {
+	c := &Curve{X: xs, Y: make([]float64, len(xs))}
+	norm := 1 / (float64(len(vals)) * bandwidth * math.Sqrt(2*math.Pi))
+	for i, x := range xs {
+		var s float64
+		for _, v := range vals {
+			if math.IsNaN(v) {
+				continue
+			}
+			z := (x - v) / bandwidth
+			s += math.Exp(-0.5 * z * z)
+		}
+		c.Y[i] = s * norm
+	}
+	return c
+}
+
+// Linspace returns n evenly spaced values from lo to hi inclusive. If n <= 0, 512 is used.
+func Linspace(lo, hi float64, n int) []float64 {
+	if n <= 0 {
+		n = 512
+	}
+	if n == 1 {
+		return []float64{lo}
+	}
+	xs := make([]float64, n)
+	step := (hi - lo) / float64(n-1)
+	for i := range xs {
+		xs[i] = lo + float64(i)*step
+	}
+	return xs
+}
+
+// ECDF returns the empirical cumulative distribution function of vals. There is one point
+// per unique value; plot it with SteppedLine to show the steps.
+func ECDF(vals []float64) (*Curve, error) {
+	sorted := Sorted(vals)
+	if len(sorted) == 0 {
+		return nil, fmt.Errorf("stats: no values for ECDF")
+	}
+	c := &Curve{}
+	n := float64(len(sorted))
+	for i, v := range sorted {
+		if i+1 < len(sorted) && sorted[i+1] == v {
+			continue
+		}
+		c.X = append(c.X, v)
+		c.Y = append(c.Y, float64(i+1)/n)
+	}
+	return c, nil
+}
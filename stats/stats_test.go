@@ -0,0 +1,251 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantile(t *testing.T) {
+	s := []float64{1, 2, 3, 4, 5}
+	for _, c := range []struct {
+		p, want float64
+	}{{0, 1}, {0.25, 2}, {0.5, 3}, {0.6, 3.4}, {1, 5}} {
+		if got := Quantile(s, c.p); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("Quantile(%v): got %v, want %v", c.p, got, c.want)
+		}
+	}
+	if !math.IsNaN(Quantile(nil, 0.5)) {
+		t.Errorf("expected NaN for empty quantile")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	vals := []float64{0, 0, 1, 2, 3, 4, 5, 6, 7, math.NaN()}
+	h, err := Histogram(vals, &HistOptions{Binning: Explicit, N: 7})
+	if err != nil {
+		t.Fatalf("error making histogram: %+v", err)
+	}
+	if len(h.Counts) != 7 || len(h.Edges) != 8 || len(h.Labels()) != 7 {
+		t.Fatalf("unexpected number of bins: %v %v", h.Counts, h.Edges)
+	}
+	var total float64
+	for _, c := range h.Counts {
+		total += c
+	}
+	if total != 9 {
+		t.Fatalf("expected 9 values counted, got %v", total)
+	}
+	if h.Counts[0] != 2 || h.Counts[6] != 2 {
+		t.Fatalf("expected the first and last bins to have 2 values: %v", h.Counts)
+	}
+
+	// a Min of 0 must be respected rather than treated as unset.
+	lo := 0.0
+	h, err = Histogram([]float64{1, 2, 3, 4}, &HistOptions{Min: &lo, Binning: Explicit, N: 4})
+	if err != nil {
+		t.Fatalf("error making histogram: %+v", err)
+	}
+	if h.Edges[0] != 0 || h.Edges[4] != 4 || h.Counts[0] != 0 {
+		t.Fatalf("expected range from 0 to 4, got %v %v", h.Edges, h.Counts)
+	}
+}
+
+func TestHistogramBinning(t *testing.T) {
+	vals := make([]float64, 1000)
+	for i := range vals {
+		vals[i] = float64(i % 100)
+	}
+	for _, b := range []Binning{Sturges, Scott, FreedmanDiaconis} {
+		h, err := Histogram(vals, &HistOptions{Binning: b, Density: true})
+		if err != nil {
+			t.Fatalf("error making histogram: %+v", err)
+		}
+		var area float64
+		for i, c := range h.Counts {
+			area += c * (h.Edges[i+1] - h.Edges[i])
+		}
+		if math.Abs(area-1) > 1e-9 {
+			t.Errorf("binning %d: expected density to have area 1, got %v", b, area)
+		}
+	}
+	// Sturges: ceil(log2(1000)) + 1
+	h, _ := Histogram(vals, nil)
+	if len(h.Counts) != 11 {
+		t.Errorf("expected 11 bins with Sturges, got %d", len(h.Counts))
+	}
+
+	h, err := Histogram([]float64{3, 3, 3}, nil)
+	if err != nil {
+		t.Fatalf("error making histogram of constant values: %+v", err)
+	}
+	if h.Edges[0] >= 3 || h.Edges[len(h.Edges)-1] <= 3 {
+		t.Errorf("expected range to span 3: %v", h.Edges)
+	}
+
+	if _, err := Histogram(vals, &HistOptions{Breaks: []float64{0, 50, 20}}); err == nil {
+		t.Errorf("expected error with unsorted breaks")
+	}
+	if _, err := Histogram(nil, nil); err == nil {
+		t.Errorf("expected error with no values")
+	}
+
+	// an outlier far from near-identical values would need billions of bins.
+	skewed := make([]float64, 1001)
+	for i := range skewed {
+		skewed[i] = 1 + float64(i)*1e-9
+	}
+	skewed[1000] = 1e6
+	for _, b := range []Binning{Scott, FreedmanDiaconis} {
+		h, err := Histogram(skewed, &HistOptions{Binning: b})
+		if err != nil {
+			t.Fatalf("error making histogram of skewed values: %+v", err)
+		}
+		if len(h.Counts) > len(skewed) {
+			t.Errorf("binning %d: expected at most %d bins, got %d", b, len(skewed), len(h.Counts))
+		}
+	}
+
+	h, err = Histogram([]float64{1, 2, math.Inf(1), math.Inf(-1)}, nil)
+	if err != nil {
+		t.Fatalf("error making histogram with infinite values: %+v", err)
+	}
+	for _, e := range h.Edges {
+		if math.IsInf(e, 0) || math.IsNaN(e) {
+			t.Fatalf("expected finite edges, got %v", h.Edges)
+		}
+	}
+	var n float64
+	for _, c := range h.Counts {
+		n += c
+	}
+	if n != 2 {
+		t.Errorf("expected infinite values to be left out, got %v values", n)
+	}
+}
+
+func TestKDE(t *testing.T) {
+	vals := []float64{-1, -0.5, 0, 0, 0.5, 1}
+	c, err := KDE(vals, 0, 200)
+	if err != nil {
+		t.Fatalf("error making KDE: %+v", err)
+	}
+	if len(c.X) != 200 || len(c.Y) != 200 {
+		t.Fatalf("expected 200 points")
+	}
+	var area float64
+	for i := 1; i < len(c.X); i++ {
+		area += (c.X[i] - c.X[i-1]) * (c.Y[i] + c.Y[i-1]) / 2
+	}
+	if math.Abs(area-1) > 0.01 {
+		t.Errorf("expected KDE to have area 1, got %v", area)
+	}
+}
+
+func TestECDF(t *testing.T) {
+	c, err := ECDF([]float64{3, 1, 2, 2})
+	if err != nil {
+		t.Fatalf("error making ECDF: %+v", err)
+	}
+	want := []float64{0.25, 0.75, 1}
+	if len(c.X) != 3 {
+		t.Fatalf("expected 3 unique values, got %v", c.X)
+	}
+	for i, w := range want {
+		if c.Y[i] != w {
+			t.Errorf("ECDF at %v: got %v, want %v", c.X[i], c.Y[i], w)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	vals := []float64{1, 2, 3, 4, 5, 6, 7, 8, 100}
+	b, err := Summarize(vals, true)
+	if err != nil {
+		t.Fatalf("error summarizing: %+v", err)
+	}
+	if b.Median != 5 || b.Q1 != 3 || b.Q3 != 7 {
+		t.Fatalf("unexpected quartiles: %+v", b)
+	}
+	if b.WhiskerMax != 8 || b.WhiskerMin != 1 || len(b.Outliers) != 1 || b.Outliers[0] != 100 {
+		t.Fatalf("unexpected whiskers or outliers: %+v", b)
+	}
+	b, _ = Summarize(vals, false)
+	if b.WhiskerMax != 100 || len(b.Outliers) != 0 {
+		t.Fatalf("expected whiskers to extend to max: %+v", b)
+	}
+}
+
+func TestPolyFit(t *testing.T) {
+	xs := []float64{0, 1, 2, 3, 4}
+	ys := []float64{1, 3, 5, 7, math.NaN()}
+	coef, r2, err := PolyFit(xs, ys, 1)
+	if err != nil {
+		t.Fatalf("error fitting: %+v", err)
+	}
+	if math.Abs(coef[0]-1) > 1e-9 || math.Abs(coef[1]-2) > 1e-9 || math.Abs(r2-1) > 1e-9 {
+		t.Fatalf("unexpected fit: %v %v", coef, r2)
+	}
+	ys = []float64{1, 2, 5, 10, 17}
+	coef, _, err = PolyFit(xs, ys, 2)
+	if err != nil {
+		t.Fatalf("error fitting: %+v", err)
+	}
+	if math.Abs(Poly(coef, 5)-26) > 1e-6 {
+		t.Fatalf("unexpected quadratic fit: %v", coef)
+	}
+	if _, _, err := PolyFit([]float64{1, 1, 1}, []float64{1, 2, 3}, 1); err == nil {
+		t.Fatalf("expected error fitting a line to a single X")
+	}
+
+	// years make the powers of x too large to solve the system directly.
+	xs, ys = nil, nil
+	for x := 1990.0; x <= 2020; x++ {
+		d := x - 2000
+		xs = append(xs, x)
+		ys = append(ys, 1+2*d+0.5*d*d-0.01*d*d*d)
+	}
+	coef, r2, err = PolyFit(xs, ys, 3)
+	if err != nil {
+		t.Fatalf("error fitting: %+v", err)
+	}
+	if math.Abs(r2-1) > 1e-9 {
+		t.Fatalf("expected an exact fit of years, got R² %v", r2)
+	}
+	for i, x := range xs {
+		if math.Abs(Poly(coef, x)-ys[i]) > 1e-4 {
+			t.Fatalf("unexpected fit at %v: %v != %v", x, Poly(coef, x), ys[i])
+		}
+	}
+}
+
+func TestLowess(t *testing.T) {
+	var xs, ys []float64
+	for i := 0; i < 50; i++ {
+		xs = append(xs, float64(49-i))
+		ys = append(ys, 2*float64(49-i)+1)
+	}
+	// an outlier is down-weighted by the robust iterations.
+	ys[25] = 1000
+	c, err := Lowess(xs, ys, 0.5, 3)
+	if err != nil {
+		t.Fatalf("error in lowess: %+v", err)
+	}
+	for i, x := range c.X {
+		if math.Abs(c.Y[i]-(2*x+1)) > 0.1 {
+			t.Fatalf("unexpected lowess at %v: %v", x, c.Y[i])
+		}
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	c, err := MovingAverage([]float64{3, 2, 1, 0}, []float64{3, 2, 1, 0}, 3)
+	if err != nil {
+		t.Fatalf("error in moving average: %+v", err)
+	}
+	want := []float64{0.5, 1, 2, 2.5}
+	for i, w := range want {
+		if c.Y[i] != w {
+			t.Fatalf("moving average: got %v, want %v", c.Y, want)
+		}
+	}
+}
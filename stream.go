@@ -0,0 +1,246 @@
+package chartjs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Stream serves a Chart that grows as points are appended to it. The page at its root receives
+// each point from {root}/events as a Server-Sent Event. Append is safe to call from any goroutine.
+// Mount it at a path that ends in "/" as with Handler.
+type Stream struct {
+	// Options are sent to SaveCharts to render the page.
+	Options map[string]interface{}
+
+	mu      sync.Mutex
+	chart   Chart
+	points  []*points
+	windows []int
+	clients map[chan []byte]bool
+	closed  bool
+}
+
+// points holds the streamed values of a dataset.
+type points struct {
+	x []float64
+	y []float64
+}
+
+func (p *points) Xs() []float64 { return p.x }
+func (p *points) Ys() []float64 { return p.y }
+func (p *points) Rs() []float64 { return nil }
+
+// clientBuffer is the number of points that may be queued for a browser. A browser that falls
+// further behind is disconnected and receives all of the data when it reconnects.
+const clientBuffer = 1024
+
+// NewStream returns a Stream of c. The values already in the datasets of c are copied.
+func NewStream(c Chart) *Stream {
+	s := &Stream{chart: c, clients: make(map[chan []byte]bool)}
+	s.chart.Data.Datasets = append([]Dataset{}, c.Data.Datasets...)
+	for i, d := range s.chart.Data.Datasets {
+		p := &points{}
+		if d.Data != nil {
+			p.x = append(p.x, d.Data.Xs()...)
+			p.y = append(p.y, d.Data.Ys()...)
+		}
+		s.points = append(s.points, p)
+		s.windows = append(s.windows, 0)
+		s.chart.Data.Datasets[i].Data = p
+	}
+	return s
+}
+
+// SetWindow limits the dataset to its last n points. If n is 0, all points are kept.
+func (s *Stream) SetWindow(dataset, n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if dataset < 0 || dataset >= len(s.points) {
+		return fmt.Errorf("chart: no dataset %d in stream", dataset)
+	}
+	if n < 0 {
+		return fmt.Errorf("chart: stream window must be >= 0")
+	}
+	s.windows[dataset] = n
+	s.points[dataset].trim(n)
+	return nil
+}
+
+func (p *points) trim(n int) {
+	if n > 0 && len(p.x) > n {
+		p.x = append(p.x[:0], p.x[len(p.x)-n:]...)
+		p.y = append(p.y[:0], p.y[len(p.y)-n:]...)
+	}
+}
+
+// Append adds a point to the dataset and sends it to the connected browsers.
+func (s *Stream) Append(dataset int, x, y float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if dataset < 0 || dataset >= len(s.points) {
+		return fmt.Errorf("chart: no dataset %d in stream", dataset)
+	}
+	p := s.points[dataset]
+	p.x = append(p.x, x)
+	p.y = append(p.y, y)
+	p.trim(s.windows[dataset])
+
+	d := s.chart.Data.Datasets[dataset]
+	xf, yf := d.XFloatFormat, d.YFloatFormat
+	if xf == "" {
+		xf = XFloatFormat
+	}
+	if yf == "" {
+		yf = YFloatFormat
+	}
+	// the point is placed on the axes like the rest of the data.
+	scales := s.chart.Options.Scales
+	x = findAxis(scales.XAxes, d.XAxisID).Transform(x)
+	y = findAxis(scales.YAxes, d.YAxisID).Transform(y)
+	if math.IsNaN(x) {
+		// a point without an x can't be drawn and is left out of the reset event too.
+		return nil
+	}
+	msg := []byte(fmt.Sprintf(`{"dataset":%d,"x":%s,"y":%s,"max":%d}`, dataset,
+		formatFloat(xf, x), formatFloat(yf, y), s.windows[dataset]))
+	for c := range s.clients {
+		select {
+		case c <- msg:
+		default:
+			// drop a client that can't keep up.
+			delete(s.clients, c)
+			close(c)
+		}
+	}
+	return nil
+}
+
+// Close disconnects all browsers and stops accepting new connections to the events.
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for c := range s.clients {
+		delete(s.clients, c)
+		close(c)
+	}
+}
+
+const streamJS = `
+var pending = false;
+var source = new EventSource("events");
+source.addEventListener("reset", function(e) {
+	charts[0].data = JSON.parse(e.data).data;
+	charts[0].update();
+});
+source.onmessage = function(e) {
+	var p = JSON.parse(e.data), ds = charts[0].data.datasets[p.dataset];
+	ds.data.push({x: p.x, y: p.y});
+	if (p.max > 0 && ds.data.length > p.max) {
+		ds.data.splice(0, ds.data.length - p.max);
+	}
+	if (!pending) {
+		pending = true;
+		window.requestAnimationFrame(function() {
+			pending = false;
+			charts[0].update();
+		});
+	}
+};
+`
+
+// ServeHTTP implements http.Handler.
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch strings.TrimPrefix(r.URL.Path, "/") {
+	case "":
+		s.servePage(w)
+	case "events":
+		s.serveEvents(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Stream) servePage(w http.ResponseWriter) {
+	s.mu.Lock()
+	c := s.chart
+	c.Data.Datasets = append([]Dataset{}, s.chart.Data.Datasets...)
+	for i, p := range s.points {
+		c.Data.Datasets[i].Data = &points{x: append([]float64{}, p.x...), y: append([]float64{}, p.y...)}
+	}
+	s.mu.Unlock()
+
+	tmap := make(map[string]interface{}, len(s.Options)+1)
+	for k, v := range s.Options {
+		tmap[k] = v
+	}
+	custom := streamJS
+	if v, ok := tmap["custom"]; ok {
+		custom += fmt.Sprint(v)
+	}
+	tmap["custom"] = template.JS(custom)
+
+	var buf bytes.Buffer
+	if err := SaveCharts(&buf, tmap, c); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	buf.WriteTo(w)
+}
+
+func (s *Stream) serveEvents(w http.ResponseWriter, r *http.Request) {
+	f, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "chart: streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	c := make(chan []byte, clientBuffer)
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		http.Error(w, "chart: stream is closed", http.StatusServiceUnavailable)
+		return
+	}
+	// the current points are sent first so that a reconnecting browser doesn't miss any.
+	reset, err := json.Marshal(s.chart)
+	if err == nil {
+		s.clients[c] = true
+	}
+	s.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		s.mu.Lock()
+		if s.clients[c] {
+			delete(s.clients, c)
+			close(c)
+		}
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprintf(w, "event: reset\ndata: %s\n\n", reset)
+	f.Flush()
+	for {
+		select {
+		case msg, ok := <-c:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", msg)
+			f.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
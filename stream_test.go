@@ -0,0 +1,125 @@
+package chartjs
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	c := Chart{Type: Line}
+	c.AddDataset(Dataset{Data: XY{X: []float64{0}, Y: []float64{0}}, Label: "a"})
+	c.AddDataset(Dataset{Label: "b"})
+	s := NewStream(c)
+	if err := s.SetWindow(1, 2); err != nil {
+		t.Fatalf("error setting window: %+v", err)
+	}
+	if err := s.Append(2, 0, 0); err == nil {
+		t.Fatalf("expected error appending to a missing dataset")
+	}
+
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatalf("error connecting to events: %+v", err)
+	}
+	defer resp.Body.Close()
+	rdr := bufio.NewReader(resp.Body)
+	next := func() string {
+		var lines []string
+		for {
+			line, err := rdr.ReadString('\n')
+			if err != nil {
+				t.Fatalf("error reading event: %+v", err)
+			}
+			if line == "\n" {
+				return strings.Join(lines, "")
+			}
+			lines = append(lines, line)
+		}
+	}
+	if ev := next(); !strings.HasPrefix(ev, "event: reset\n") || !strings.Contains(ev, `"x":0.00`) {
+		t.Fatalf("expected reset event with the current points, got %q", ev)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := s.Append(1, float64(i), 1); err != nil {
+				t.Errorf("error appending: %+v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < 3; i++ {
+		if ev := next(); !strings.Contains(ev, `"dataset":1`) || !strings.Contains(ev, `"max":2`) {
+			t.Fatalf("unexpected point event: %q", ev)
+		}
+	}
+	if n := len(s.points[1].x); n != 2 {
+		t.Fatalf("expected window to keep 2 points, got %d", n)
+	}
+
+	page, err := http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatalf("error getting page: %+v", err)
+	}
+	b, _ := ioutil.ReadAll(page.Body)
+	page.Body.Close()
+	if !strings.Contains(string(b), "EventSource") {
+		t.Fatalf("expected EventSource in page")
+	}
+
+	s.Close()
+	if _, err := rdr.ReadString('\n'); err == nil {
+		t.Fatalf("expected events to end after Close")
+	}
+}
+
+func TestStreamTransform(t *testing.T) {
+	c := Chart{Type: Line}
+	c.AddYAxis(Axis{Type: Symlog})
+	c.AddXAxis(Axis{Type: Log, Log: &LogOptions{Safety: LogDrop}})
+	c.AddDataset(Dataset{Label: "a"})
+	s := NewStream(c)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	defer s.Close()
+
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatalf("error connecting to events: %+v", err)
+	}
+	defer resp.Body.Close()
+	rdr := bufio.NewReader(resp.Body)
+	next := func() string {
+		for {
+			line, err := rdr.ReadString('\n')
+			if err != nil {
+				t.Fatalf("error reading event: %+v", err)
+			}
+			if strings.HasPrefix(line, "data: {\"dataset\"") {
+				return line
+			}
+		}
+	}
+
+	if err := s.Append(0, -1, 5); err != nil {
+		t.Fatalf("error appending: %+v", err)
+	}
+	if err := s.Append(0, 10, 1000); err != nil {
+		t.Fatalf("error appending: %+v", err)
+	}
+	// the point with an x that can't be shown on the log axis is not sent.
+	if ev := next(); !strings.Contains(ev, `"x":10.00`) || !strings.Contains(ev, `"y":3.00`) {
+		t.Fatalf("expected the transformed point, got %q", ev)
+	}
+}
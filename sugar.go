@@ -1,9 +1,14 @@
 package chartjs
 
 import (
+	"bytes"
+	"crypto/rand"
 	"encoding/json"
+	"fmt"
 	"html/template"
 	"io"
+
+	"github.com/brentp/go-chartjs/stats"
 )
 
 // this file implements some syntactic sugar for creating charts
@@ -11,14 +16,17 @@ import (
 // JQuery holds the path to hosted JQuery
 var JQuery = "https://code.jquery.com/jquery-2.2.4.min.js"
 
-// ChartJS holds the path to hosted ChartJS
-var ChartJS = "https://cdnjs.cloudflare.com/ajax/libs/Chart.js/2.6.0/Chart.bundle.js"
+// ChartJS holds the path to hosted ChartJS. It is version 2.9.4 as Dataset.Order needs 2.8 or
+// later; earlier 2.x versions ignore it.
+var ChartJS = "https://cdnjs.cloudflare.com/ajax/libs/Chart.js/2.9.4/Chart.bundle.js"
 
 const tmpl = `<!DOCTYPE html>
 <html>
     <head>
 		<script src="{{ index . "JQuery" }}"></script>
 		<script src="{{ index . "ChartJS" }}"></script>
+		{{ range index . "scripts" }}<script src="{{ . }}"></script>
+		{{ end }}
 		<script>
 		{{ index . "extra"}}
 		</script>
@@ -26,8 +34,9 @@ const tmpl = `<!DOCTYPE html>
     <body>
 	{{ $height := index . "height" }}
 	{{ $width := index . "width" }}
+	{{ $ids := index . "ids" }}
 	{{ range $i, $json := index . "charts" }}
-	<canvas id="canvas{{ $i }}" style="height:{{ $height }}px;width:{{ $width }}px"></canvas>
+	<canvas id="{{ index $ids $i }}" style="height:{{ $height }}px;width:{{ $width }}px"></canvas>
 		<hr>
 	{{ end }}
 	{{ index . "customHTML" }}
@@ -35,9 +44,10 @@ const tmpl = `<!DOCTYPE html>
     <script>
 	Chart.defaults.line.cubicInterpolationMode = 'monotone';
 	Chart.defaults.global.animation.duration = 0;
+	{{ index . "plugins" }}
 	var charts = []
 	{{ range $i, $json := index . "charts" }}
-		var ctx = document.getElementById("canvas{{ $i }}").getContext("2d");
+		var ctx = document.getElementById("{{ index $ids $i }}").getContext("2d");
 		var chart = new Chart(ctx, {{ $json }});
 		charts.push(chart)
 	{{ end }}
@@ -45,11 +55,94 @@ const tmpl = `<!DOCTYPE html>
     </script>
 </html>`
 
+// fragmentTmpl is used by SaveFragment. The canvases have unique IDs and the charts are created
+// once Chart.js has loaded so that it may be embedded in other pages and notebooks.
+const fragmentTmpl = `<div>
+	<script src="{{ index . "ChartJS" }}"></script>
+	{{ range index . "scripts" }}<script src="{{ . }}"></script>
+	{{ end }}` + fragmentBody + `</div>`
+
+// fragmentBody holds the canvases and javascript of a fragment without the script tags.
+const fragmentBody = `
+	{{ $height := index . "height" }}
+	{{ $width := index . "width" }}
+	{{ $ids := index . "ids" }}
+	{{ range $i, $json := index . "charts" }}
+	<canvas id="{{ index $ids $i }}" style="height:{{ $height }}px;width:{{ $width }}px"></canvas>
+	{{ end }}
+	{{ index . "customHTML" }}
+	<script>
+	(function() {
+		function draw() {
+			if (typeof Chart === "undefined") {
+				return setTimeout(draw, 50);
+			}
+			{{ index . "plugins" }}
+			var charts = [];
+			{{ range $i, $json := index . "charts" }}
+			charts.push(new Chart(document.getElementById("{{ index $ids $i }}").getContext("2d"), {{ $json }}));
+			{{ end }}
+			{{ with index . "global" }}window[{{ . }}] = charts;{{ end }}
+			{{ index . "custom" }}
+		}
+		draw();
+	})();
+	</script>
+`
+
 // SaveCharts writes the charts and the required HTML to an io.Writer
 func SaveCharts(w io.Writer, tmap map[string]interface{}, charts ...Chart) error {
 	if tmap == nil {
 		tmap = make(map[string]interface{})
 	}
+	if _, ok := tmap["prefix"]; !ok {
+		tmap["prefix"] = "canvas"
+	}
+	return save(w, tmap, tmpl, charts)
+}
+
+// SaveFragment writes the charts as an HTML fragment, rather than a full page, that can be
+// embedded in another page. The canvases have IDs that start with a random "prefix" unless
+// one is given in tmap or set with Chart.ID. The javascript is wrapped in a function so nothing
+// is declared globally; "custom" runs with the charts in a local "charts" array and, if
+// tmap["global"] names a variable, the array is also assigned to window[global].
+func SaveFragment(w io.Writer, tmap map[string]interface{}, charts ...Chart) error {
+	if tmap == nil {
+		tmap = make(map[string]interface{})
+	}
+	if _, ok := tmap["prefix"]; !ok {
+		prefix, err := uniquePrefix()
+		if err != nil {
+			return err
+		}
+		tmap["prefix"] = prefix
+	}
+	return save(w, tmap, fragmentTmpl, charts)
+}
+
+// uniquePrefix returns a random prefix for canvas IDs.
+func uniquePrefix() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("chartjs-%x-", b), nil
+}
+
+// MIMEBundle returns the chart as "text/html" for display in Jupyter notebooks such as gophernotes.
+func (c Chart) MIMEBundle() map[string]interface{} {
+	var buf bytes.Buffer
+	if err := SaveFragment(&buf, nil, c); err != nil {
+		return map[string]interface{}{"text/plain": err.Error()}
+	}
+	return map[string]interface{}{"text/html": buf.String()}
+}
+
+// save fills in the defaults of tmap and executes the template.
+func save(w io.Writer, tmap map[string]interface{}, defaultTemplate string, charts []Chart) error {
+	if tmap == nil {
+		tmap = make(map[string]interface{})
+	}
 
 	if _, ok := tmap["height"]; !ok {
 		tmap["height"] = 400
@@ -57,14 +150,27 @@ func SaveCharts(w io.Writer, tmap map[string]interface{}, charts ...Chart) error
 	if _, ok := tmap["width"]; !ok {
 		tmap["width"] = 400
 	}
+	all := append([]Chart{}, charts...)
 	jscharts := make([]template.JS, 0, len(charts))
-	for _, c := range charts {
+	ids := make([]string, 0, len(charts))
+	seen := make(map[string]bool, len(charts))
+	for i, c := range charts {
 		cjson, err := json.Marshal(c)
 		if err != nil {
 			return err
 		}
 		jscharts = append(jscharts, template.JS(cjson))
+		id := c.ID
+		if id == "" {
+			id = fmt.Sprintf("%v%d", tmap["prefix"], i)
+		}
+		if seen[id] {
+			return fmt.Errorf("chart: duplicate element id %q", id)
+		}
+		seen[id] = true
+		ids = append(ids, id)
 	}
+	tmap["ids"] = ids
 	for k, v := range tmap {
 		if chart, ok := v.(Chart); ok {
 			cjson, err := json.Marshal(chart)
@@ -72,8 +178,11 @@ func SaveCharts(w io.Writer, tmap map[string]interface{}, charts ...Chart) error
 				return err
 			}
 			tmap[k] = template.JS(cjson)
+			all = append(all, chart)
 		}
 	}
+	tmap["plugins"] = plugins(all)
+	tmap["scripts"] = scripts(all)
 
 	tmap["charts"] = jscharts
 	if _, ok := tmap["JQuery"]; !ok {
@@ -89,7 +198,7 @@ func SaveCharts(w io.Writer, tmap map[string]interface{}, charts ...Chart) error
 		tmap["customHTML"] = ""
 	}
 	if _, ok := tmap["template"]; !ok {
-		tmap["template"] = tmpl
+		tmap["template"] = defaultTemplate
 	}
 	t, err := template.New("chartjs").Parse(tmap["template"].(string))
 	if err != nil {
@@ -98,6 +207,108 @@ func SaveCharts(w io.Writer, tmap map[string]interface{}, charts ...Chart) error
 	return t.Execute(w, tmap)
 }
 
+// pluginJS holds the inline javascript of each plugin by name.
+var pluginJS = map[string]string{
+	"errorBars": errorBarsPlugin,
+	"errorBand": errorBandPlugin,
+	"heatmap":   heatmapPlugin,
+	"logTicks":  logTicksPlugin,
+	"breaks":    breaksPlugin,
+}
+
+// plugins returns the inline javascript needed to draw the charts. Each plugin is registered once
+// even if several outputs are embedded in the same page.
+func plugins(charts []Chart) template.JS {
+	var buf bytes.Buffer
+	seen := make(map[string]bool)
+	for _, c := range charts {
+		for _, name := range c.plugins() {
+			if seen[name] {
+				continue
+			}
+			if len(seen) == 0 {
+				buf.WriteString("Chart.goChartjs = Chart.goChartjs || {};\n")
+			}
+			seen[name] = true
+			fmt.Fprintf(&buf, "if (!Chart.goChartjs.%s) {\nChart.goChartjs.%s = true;\n%s}\n", name, name, pluginJS[name])
+		}
+	}
+	return template.JS(buf.String())
+}
+
+// plugins returns the names of the inline javascript plugins required by the chart.
+func (c Chart) plugins() []string {
+	var p []string
+	for _, d := range c.Data.Datasets {
+		if _, ok := d.Data.(ErrorValues); ok && d.ErrorMode == ErrorBars {
+			p = append(p, "errorBars")
+		}
+		if _, ok := d.Data.(ErrorValues); ok && d.ErrorMode == ErrorBand {
+			p = append(p, "errorBand")
+		}
+		if d.Matrix != nil {
+			p = append(p, "heatmap")
+		}
+	}
+	for _, a := range append(append([]Axis{}, c.Options.Scales.XAxes...), c.Options.Scales.YAxes...) {
+		if _, ok := a.logOptions(); ok {
+			p = append(p, "logTicks")
+		}
+		if len(a.breaks()) > 0 {
+			p = append(p, "breaks")
+		}
+	}
+	return p
+}
+
+// scripts returns the source of each external plugin needed to draw the charts.
+func scripts(charts []Chart) []string {
+	var srcs []string
+	seen := make(map[string]bool)
+	for _, c := range charts {
+		for _, src := range c.scripts() {
+			if !seen[src] {
+				seen[src] = true
+				srcs = append(srcs, src)
+			}
+		}
+	}
+	return srcs
+}
+
+// scripts returns the external plugins required by the chart.
+func (c Chart) scripts() []string {
+	uses := func(t chartType) bool {
+		if c.ResolvedType() == t {
+			return true
+		}
+		for _, d := range c.Data.Datasets {
+			if d.Type == t {
+				return true
+			}
+		}
+		return false
+	}
+	var s []string
+	if uses(BoxPlot) || uses(Violin) {
+		s = append(s, BoxPlotJS)
+	}
+	if uses(Heatmap) {
+		s = append(s, MatrixJS)
+	}
+	return s
+}
+
+// Histogram bins vals and returns a Bar Dataset along with the labels of the bins
+// to be used as the Data.Labels of the chart.
+func Histogram(vals []float64, opts *stats.HistOptions) (Dataset, []string, error) {
+	h, err := stats.Histogram(vals, opts)
+	if err != nil {
+		return Dataset{}, nil, err
+	}
+	return Dataset{Data: h, Type: Bar}, h.Labels(), nil
+}
+
 // SaveHTML writes the chart and minimal HTML to an io.Writer.
 func (c Chart) SaveHTML(w io.Writer, tmap map[string]interface{}) error {
 	return SaveCharts(w, tmap, c)
@@ -0,0 +1,94 @@
+package chartjs
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSaveFragment(t *testing.T) {
+	c := Chart{Type: Bar}
+	c.AddDataset(Dataset{Data: XY{X: []float64{1, 2}, Y: []float64{3, 4}}, Label: "bars"})
+
+	ids := regexp.MustCompile(`<canvas id="(chartjs-[0-9a-f]+-)0"`)
+	var prefixes []string
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		if err := SaveFragment(&buf, nil, c); err != nil {
+			t.Fatalf("error saving fragment: %+v", err)
+		}
+		s := buf.String()
+		if strings.Contains(s, "<!DOCTYPE") || strings.Contains(s, "<body") {
+			t.Fatalf("expected a fragment, got a page")
+		}
+		m := ids.FindStringSubmatch(s)
+		if m == nil {
+			t.Fatalf("expected a prefixed canvas id in %s", s)
+		}
+		if !strings.Contains(s, `document.getElementById("`+m[1]+`0")`) {
+			t.Fatalf("expected the chart to be drawn on its canvas")
+		}
+		prefixes = append(prefixes, m[1])
+	}
+	if prefixes[0] == prefixes[1] {
+		t.Fatalf("expected unique canvas ids, got %s twice", prefixes[0])
+	}
+
+	var buf bytes.Buffer
+	if err := SaveFragment(&buf, map[string]interface{}{"prefix": "mine-"}, c); err != nil {
+		t.Fatalf("error saving fragment: %+v", err)
+	}
+	if !strings.Contains(buf.String(), `id="mine-0"`) {
+		t.Fatalf("expected the given prefix to be used")
+	}
+}
+
+func TestMIMEBundle(t *testing.T) {
+	c := Chart{Type: Line}
+	c.AddDataset(Dataset{Data: XY{X: []float64{1, 2}, Y: []float64{3, 4}}})
+	b := c.MIMEBundle()
+	html, ok := b["text/html"].(string)
+	if !ok || !strings.Contains(html, "new Chart(") {
+		t.Fatalf("expected text/html in bundle, got %v", b)
+	}
+
+	c.Data.Datasets[0].Data = XY{X: []float64{1, 2}, Y: []float64{3}}
+	if _, ok := c.MIMEBundle()["text/plain"]; !ok {
+		t.Fatalf("expected an error for mismatched values")
+	}
+}
+
+func TestElementIDs(t *testing.T) {
+	a := Chart{Type: Line, ID: "first"}
+	a.AddDataset(Dataset{Data: XY{X: []float64{1, 2}, Y: []float64{3, 4}}})
+	b := a
+	b.ID = ""
+
+	var buf bytes.Buffer
+	if err := SaveFragment(&buf, map[string]interface{}{"prefix": "p-", "global": "myCharts"}, a, b); err != nil {
+		t.Fatalf("error saving fragment: %+v", err)
+	}
+	s := buf.String()
+	for _, want := range []string{`<canvas id="first"`, `<canvas id="p-1"`, `window["myCharts"] = charts;`, "(function() {"} {
+		if !strings.Contains(s, want) {
+			t.Fatalf("expected %q in %s", want, s)
+		}
+	}
+	if strings.Contains(s, "var ctx") {
+		t.Fatalf("expected no global variables in fragment")
+	}
+
+	buf.Reset()
+	if err := SaveCharts(&buf, nil, b, b); err != nil {
+		t.Fatalf("error saving charts: %+v", err)
+	}
+	if !strings.Contains(buf.String(), `id="canvas1"`) {
+		t.Fatalf("expected the default canvas ids in page")
+	}
+
+	b.ID = "first"
+	if err := SaveFragment(&buf, nil, a, b); err == nil {
+		t.Fatalf("expected an error for duplicate ids")
+	}
+}
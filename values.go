@@ -0,0 +1,91 @@
+package chartjs
+
+import "github.com/brentp/go-chartjs/stats"
+
+// Number is any integer or floating point type that the constructors of Values accept.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Floats converts a slice of numbers to float64. A []float64 is returned as it is.
+func Floats[T Number](vals []T) []float64 {
+	if v, ok := any(vals).([]float64); ok {
+		return v
+	}
+	out := make([]float64, len(vals))
+	for i, v := range vals {
+		out[i] = float64(v)
+	}
+	return out
+}
+
+// XY satisfies Values with points for Line and scatter charts.
+type XY struct {
+	X []float64
+	Y []float64
+}
+
+// Xs returns the x values.
+func (v XY) Xs() []float64 { return v.X }
+
+// Ys returns the y values.
+func (v XY) Ys() []float64 { return v.Y }
+
+// Rs returns nil as the points have no radii.
+func (v XY) Rs() []float64 { return nil }
+
+// NewXY returns the points of x and y, which must be of the same length.
+func NewXY[X, Y Number](x []X, y []Y) XY {
+	return XY{X: Floats(x), Y: Floats(y)}
+}
+
+// XYR satisfies Values with points and radii for Bubble charts.
+type XYR struct {
+	X []float64
+	Y []float64
+	R []float64
+}
+
+// Xs returns the x values.
+func (v XYR) Xs() []float64 { return v.X }
+
+// Ys returns the y values.
+func (v XYR) Ys() []float64 { return v.Y }
+
+// Rs returns the radii.
+func (v XYR) Rs() []float64 { return v.R }
+
+// NewXYR returns the bubbles of x, y and r, which must be of the same length.
+func NewXYR[X, Y, R Number](x []X, y []Y, r []R) XYR {
+	return XYR{X: Floats(x), Y: Floats(y), R: Floats(r)}
+}
+
+// Ys satisfies Values with values but no x, as for Bar charts with Data.Labels.
+type Ys []float64
+
+// Xs returns nil as the values are placed by index.
+func (v Ys) Xs() []float64 { return nil }
+
+// Ys returns the values.
+func (v Ys) Ys() []float64 { return v }
+
+// Rs returns nil as the values have no radii.
+func (v Ys) Rs() []float64 { return nil }
+
+// NewYs returns the values of y.
+func NewYs[T Number](y []T) Ys {
+	return Ys(Floats(y))
+}
+
+// FuncValues samples f at n evenly spaced points from min to max inclusive. If n <= 0, 512
+// points are used.
+func FuncValues(f func(float64) float64, min, max float64, n int) XY {
+	xs := stats.Linspace(min, max, n)
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = f(x)
+	}
+	return XY{X: xs, Y: ys}
+}
@@ -0,0 +1,47 @@
+package chartjs
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+type celsius float32
+
+func TestValues(t *testing.T) {
+	var _ Values = XY{}
+	var _ Values = XYR{}
+	var _ Values = Ys{}
+
+	v := NewXY([]int{1, 2, 3}, []celsius{1.5, 2.5, 3.5})
+	if v.X[2] != 3 || v.Y[0] != 1.5 {
+		t.Fatalf("unexpected values: %+v", v)
+	}
+	b := NewXYR([]uint32{1}, []float64{2}, []int8{3})
+	if b.Rs()[0] != 3 {
+		t.Fatalf("unexpected radii: %v", b.Rs())
+	}
+	f := []float64{1, 2}
+	if &Floats(f)[0] != &f[0] {
+		t.Fatalf("expected []float64 not to be copied")
+	}
+
+	c := Chart{Type: Bar, Data: Data{Labels: []string{"a", "b"}}}
+	c.AddDataset(Dataset{Data: NewYs([]int{4, 5})})
+	c.AddDataset(Dataset{Data: v, Type: Line})
+	j, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("error marshaling: %+v", err)
+	}
+	if !strings.Contains(string(j), `"data":[4.00,5.00]`) || !strings.Contains(string(j), `{"x":2.00,"y":2.50}`) {
+		t.Fatalf("unexpected json: %s", j)
+	}
+}
+
+func TestFuncValues(t *testing.T) {
+	v := FuncValues(math.Sqrt, 0, 4, 5)
+	if len(v.X) != 5 || v.X[4] != 4 || v.Y[4] != 2 || v.Y[1] != 1 {
+		t.Fatalf("unexpected samples: %+v", v)
+	}
+}